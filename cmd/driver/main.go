@@ -17,6 +17,8 @@ func main() {
 		nodeId     = flag.String("node-id", "", "Specify node id where the plugin runs")
 		driverName = flag.String("driver-name", driver.DefaultDriverName, "Name for the driver")
 		port       = flag.String("port", "", "Port for the qsd grpc server")
+		qsdNs      = flag.String("qsd-namespace", "csi-qsd", "Namespace of the qsd server pods")
+		qsdSel     = flag.String("qsd-selector", "name=qsd", "Label selector of the qsd server pods, empty to resolve the node names as hostnames")
 		help       = flag.Bool("help", false, "Print help and exit")
 	)
 	flag.Parse()
@@ -26,7 +28,7 @@ func main() {
 		os.Exit(0)
 	}
 
-	drv, err := driver.NewDriver(*endpoint, *driverName, *nodeId, *port)
+	drv, err := driver.NewDriver(*endpoint, *driverName, *nodeId, *port, *qsdNs, *qsdSel)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -8,6 +8,9 @@ import (
 	"github.com/alicefr/csi-qsd/pkg/metadata"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 var (
@@ -30,6 +33,11 @@ func main() {
 		log.Fatalf("Failed creating the metadata server: %v", err)
 	}
 	metadata.RegisterMetadataServiceServer(srv, metadataServer)
+	// The metadata server has no dependencies, it is serving as soon as it listens
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("alicefr.csi.pkg.qsd.MetadataService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
 	srv.Serve(lis)
 
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alicefr/csi-qsd/pkg/driver"
+	"github.com/alicefr/csi-qsd/pkg/populator"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	kubeconfig = flag.String("kubeconfig", "", "Path of the kubeconfig, empty when running in the cluster")
+	namespace  = flag.String("namespace", "csi-qsd", "Namespace where the prime PVCs are created")
+	driverName = flag.String("driver-name", driver.DefaultDriverName, "Name of the driver provisioning the volumes")
+	workers    = flag.Int("workers", 4, "Number of PVCs populated in parallel")
+)
+
+func main() {
+	flag.Parse()
+	var config *rest.Config
+	var err error
+	if *kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		log.Fatalf("Failed getting the configuration of the cluster: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("Failed creating the Kubernetes client: %v", err)
+	}
+	dynClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("Failed creating the dynamic client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	p := populator.NewPopulator(client, dynClient, *namespace, *driverName)
+	if err := p.Run(ctx, *workers); err != nil {
+		log.Fatalf("Populator failed: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup a volume to the backup target",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to backup: %v", err)
+		}
+		id, err := cmd.Flags().GetString("backup-id")
+		if err != nil {
+			log.Fatalf("Error getting the backup id: %v", err)
+		}
+		incremental, err := cmd.Flags().GetBool("incremental")
+		if err != nil {
+			log.Fatalf("Error getting the incremental flag: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		client := qsd.NewQsdServiceClient(conn)
+		defer conn.Close()
+
+		// The full backups of large volumes can take a while
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Info("backup volume with the QSD")
+		r, err := client.BackupVolume(ctx, &qsd.BackupParams{
+			VolumeID:    image,
+			BackupID:    id,
+			Incremental: incremental,
+		})
+		if err != nil {
+			return fmt.Errorf("Error for backing up the volume %v", err)
+		}
+		if r.Parent != "" {
+			fmt.Printf("Incremental backup %s of %s with parent %s\n", r.BackupID, image, r.Parent)
+		} else {
+			fmt.Printf("Full backup %s of %s\n", r.BackupID, image)
+		}
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup in a new volume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to restore: %v", err)
+		}
+		id, err := cmd.Flags().GetString("backup-id")
+		if err != nil {
+			log.Fatalf("Error getting the backup id: %v", err)
+		}
+		source, err := cmd.Flags().GetString("source")
+		if err != nil {
+			log.Fatalf("Error getting the source volume of the backup: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		client := qsd.NewQsdServiceClient(conn)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Info("restore volume with the QSD")
+		_, err = client.RestoreVolume(ctx, &qsd.RestoreParams{
+			VolumeID:       image,
+			BackupID:       id,
+			SourceVolumeID: source,
+		})
+		if err != nil {
+			return fmt.Errorf("Error for restoring the volume %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.Flags().String("image", "", "Volume to backup")
+	backupCmd.Flags().String("backup-id", "", "ID of the backup")
+	backupCmd.Flags().Bool("incremental", false, "Only copy the data changed since the previous backup")
+	backupCmd.MarkFlagRequired("image")
+	backupCmd.MarkFlagRequired("backup-id")
+
+	rootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().String("image", "", "Volume to create")
+	restoreCmd.Flags().String("backup-id", "", "ID of the backup to restore")
+	restoreCmd.Flags().String("source", "", "Volume of the backup")
+	restoreCmd.MarkFlagRequired("image")
+	restoreCmd.MarkFlagRequired("backup-id")
+	restoreCmd.MarkFlagRequired("source")
+}
@@ -31,10 +31,38 @@ var createCmd = &cobra.Command{
 		if err != nil && source == "" {
 			log.Fatalf("Error getting size exporter: %v", err)
 		}
+		export := &qsd.ExportOptions{}
+		if export.NumQueues, err = cmd.Flags().GetUint32("num-queues"); err != nil {
+			log.Fatalf("Error getting the number of queues: %v", err)
+		}
+		if export.LogicalBlockSize, err = cmd.Flags().GetUint32("logical-block-size"); err != nil {
+			log.Fatalf("Error getting the logical block size: %v", err)
+		}
+		if export.Writethrough, err = cmd.Flags().GetBool("writethrough"); err != nil {
+			log.Fatalf("Error getting writethrough flag: %v", err)
+		}
+		if export.Discard, err = cmd.Flags().GetBool("discard"); err != nil {
+			log.Fatalf("Error getting discard flag: %v", err)
+		}
+		io := &qsd.IOOptions{}
+		if io.CacheDirect, err = cmd.Flags().GetBool("cache-direct"); err != nil {
+			log.Fatalf("Error getting cache-direct flag: %v", err)
+		}
+		if io.CacheNoFlush, err = cmd.Flags().GetBool("cache-no-flush"); err != nil {
+			log.Fatalf("Error getting cache-no-flush flag: %v", err)
+		}
+		if io.Aio, err = cmd.Flags().GetString("aio"); err != nil {
+			log.Fatalf("Error getting the aio mode: %v", err)
+		}
+		if io.DetectZeroes, err = cmd.Flags().GetString("detect-zeroes"); err != nil {
+			log.Fatalf("Error getting the detect-zeroes mode: %v", err)
+		}
 		i := &qsd.Image{
 			ID:         image,
 			Size:       size,
 			FromVolume: source,
+			Export:     export,
+			IO:         io,
 		}
 		// Create client to the QSD grpc server on the node where the volume has to be created
 		var opts []grpc.DialOption
@@ -71,5 +99,13 @@ func init() {
 	createCmd.Flags().String("image", "image", "Name of the image")
 	createCmd.Flags().Int64("size", 0, "Size of the image")
 	createCmd.Flags().String("from", "", "Name of the image to use as source to create the snapshot")
+	createCmd.Flags().Uint32("num-queues", 0, "Number of queues of the vhost-user-blk export")
+	createCmd.Flags().Uint32("logical-block-size", 0, "Logical block size of the vhost-user-blk export")
+	createCmd.Flags().Bool("writethrough", false, "Flush the writes before completing them")
+	createCmd.Flags().Bool("discard", false, "Pass the discard requests to the image file")
+	createCmd.Flags().Bool("cache-direct", false, "Bypass the page cache of the host")
+	createCmd.Flags().Bool("cache-no-flush", false, "Ignore the flush requests")
+	createCmd.Flags().String("aio", "", "AIO mode: threads, native or io_uring")
+	createCmd.Flags().String("detect-zeroes", "", "Detect the writes of zeroes: off, on or unmap")
 	createCmd.MarkFlagRequired("image")
 }
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCmd represents the health command
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the health of the server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := cmd.Flags().GetString("service")
+		if err != nil {
+			return fmt.Errorf("Error getting service flag: %v", err)
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return fmt.Errorf("Error getting timeout flag: %v", err)
+		}
+		verbose, err := cmd.Flags().GetBool("verbose")
+		if err != nil {
+			return fmt.Errorf("Error getting verbose flag: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the server:%v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		r, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return fmt.Errorf("Health check failed: %v", err)
+		}
+		fmt.Println(r.GetStatus())
+		if verbose {
+			// Details about the qemu-storage-daemon, only available on the qsd server
+			h, err := qsd.NewQsdServiceClient(conn).Health(ctx, &qsd.HealthParams{})
+			if err != nil {
+				return fmt.Errorf("Failed getting the health of the QSD: %v", err)
+			}
+			fmt.Printf("qsd: %s restarts: %d %s\n", h.GetStatus(), h.GetRestarts(), h.GetMessage())
+		}
+		if r.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("Service %q is %s", service, r.GetStatus())
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+	healthCmd.Flags().String("service", "", "Name of the service to check, empty for the overall server health")
+	healthCmd.Flags().Duration("timeout", 5*time.Second, "Timeout of the health check")
+	healthCmd.Flags().BoolP("verbose", "v", false, "Print the status of the qemu-storage-daemon")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+type progressStream interface {
+	Recv() (*qsd.Progress, error)
+}
+
+// printProgress prints the progress received from the server until the operation is done
+func printProgress(stream progressStream) error {
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\r%6.2f%%", p.Percent)
+		if p.Done {
+			fmt.Println()
+		}
+	}
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import an external image in a new volume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to import: %v", err)
+		}
+		source, err := cmd.Flags().GetString("source")
+		if err != nil {
+			log.Fatalf("Error getting the source of the image: %v", err)
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatalf("Error getting the format of the image: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		client := qsd.NewQsdServiceClient(conn)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Info("import volume with the QSD")
+		stream, err := client.ImportVolume(ctx, &qsd.ImportParams{
+			VolumeID: image,
+			Source:   source,
+			Format:   format,
+		})
+		if err != nil {
+			return fmt.Errorf("Error for importing the volume %v", err)
+		}
+		if err := printProgress(stream); err != nil {
+			return fmt.Errorf("Error for importing the volume %v", err)
+		}
+		return nil
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a volume in an image on the node",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to export: %v", err)
+		}
+		dest, err := cmd.Flags().GetString("dest")
+		if err != nil {
+			log.Fatalf("Error getting the destination of the image: %v", err)
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatalf("Error getting the format of the image: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		client := qsd.NewQsdServiceClient(conn)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Info("export volume with the QSD")
+		stream, err := client.ExportVolume(ctx, &qsd.ExportParams{
+			VolumeID:    image,
+			Destination: dest,
+			Format:      format,
+		})
+		if err != nil {
+			return fmt.Errorf("Error for exporting the volume %v", err)
+		}
+		if err := printProgress(stream); err != nil {
+			return fmt.Errorf("Error for exporting the volume %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().String("image", "", "Volume to create")
+	importCmd.Flags().String("source", "", "Path on the node or http(s) URL of the image")
+	importCmd.Flags().String("format", "", "Format of the image, detected if empty")
+	importCmd.MarkFlagRequired("image")
+	importCmd.MarkFlagRequired("source")
+
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().String("image", "", "Volume to export")
+	exportCmd.Flags().String("dest", "", "Path on the node of the exported image")
+	exportCmd.Flags().String("format", "qcow2", "Format of the exported image: qcow2, raw, vmdk or vhdx")
+	exportCmd.MarkFlagRequired("image")
+	exportCmd.MarkFlagRequired("dest")
+}
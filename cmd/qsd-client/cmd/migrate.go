@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate a volume to another node",
+	Long: `Migrate a volume to another node. The command calls the controller, the source
+exports the volume over NBD, the target copies it with a mirror job and the node label
+of the PV is moved to the target. The migration isn't live: it is rejected while the
+volume is in use and the volume cannot be published during the copy. The PV needs then
+to be re-created with the new node affinity, see examples/storageclass_replicated.yaml.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to migrate: %v", err)
+		}
+		targetNode, err := cmd.Flags().GetString("target-node")
+		if err != nil {
+			log.Fatalf("Error getting the target node: %v", err)
+		}
+		controller, err := cmd.Flags().GetString("controller")
+		if err != nil {
+			log.Fatalf("Error getting the controller endpoint: %v", err)
+		}
+		deleteSource, err := cmd.Flags().GetBool("delete-source")
+		if err != nil {
+			log.Fatalf("Error getting the delete-source flag: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(controller, opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the controller:%v", err)
+		}
+		defer conn.Close()
+		client := csiext.NewVolumeControllerClient(conn)
+
+		// The copy of large volumes can take a while
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Infof("migrate volume %s to node %s", image, targetNode)
+		r, err := client.MigrateVolume(ctx, &csiext.MigrateVolumeRequest{
+			VolumeId:     image,
+			NodeId:       targetNode,
+			DeleteSource: deleteSource,
+		})
+		if err != nil {
+			return fmt.Errorf("Error for migrating the volume %v", err)
+		}
+		log.Infof("volume %s moved to node %s", image, r.NodeId)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().String("image", "", "Volume to migrate")
+	migrateCmd.Flags().String("target-node", "", "Name of the node where the volume is migrated")
+	migrateCmd.Flags().String("controller", defaultControllerEndpoint, "Endpoint of the CSI controller")
+	migrateCmd.Flags().Bool("delete-source", false, "Delete the volume on the source once migrated")
+	migrateCmd.MarkFlagRequired("image")
+	migrateCmd.MarkFlagRequired("target-node")
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var replicaCmd = &cobra.Command{
+	Use:   "replica",
+	Short: "Replica command",
+}
+
+var replicaPromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promote the replica of a volume",
+	Long: `Promote the replica of a volume after the loss of the primary node. The command
+calls the controller, it disconnects the replica from the primary and moves the volume
+and the node label of the PV to the node of the replica. The PV needs then to be
+re-created with the new node affinity, see examples/storageclass_replicated.yaml.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to promote: %v", err)
+		}
+		controller, err := cmd.Flags().GetString("controller")
+		if err != nil {
+			log.Fatalf("Error getting the controller endpoint: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(controller, opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the controller:%v", err)
+		}
+		defer conn.Close()
+		client := csiext.NewVolumeControllerClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		log.Infof("promote the replica of %s", image)
+		r, err := client.PromoteReplica(ctx, &csiext.PromoteReplicaRequest{VolumeId: image})
+		if err != nil {
+			return fmt.Errorf("Error for promoting the replica %v", err)
+		}
+		log.Infof("volume %s moved to node %s", image, r.NodeId)
+		return nil
+	},
+}
+
+var replicaSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the asynchronous replica of a volume",
+	Long: `Copy on the asynchronous replica the writes since the previous sync, without
+waiting for the RPO. The command runs on the server of the primary.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			log.Fatalf("Error getting the image to sync: %v", err)
+		}
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		defer conn.Close()
+		client := qsd.NewQsdServiceClient(conn)
+
+		// The first sync copies the whole volume
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+		defer cancel()
+		log.Infof("sync the replica of %s", image)
+		r, err := client.SyncReplica(ctx, &qsd.Image{ID: image})
+		if err != nil {
+			return fmt.Errorf("Error for syncing the replica %v", err)
+		}
+		if !r.Success {
+			return fmt.Errorf("Error for syncing the replica %s", r.Message)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replicaCmd)
+	replicaPromoteCmd.Flags().String("image", "", "Volume to promote")
+	replicaPromoteCmd.Flags().String("controller", defaultControllerEndpoint, "Endpoint of the CSI controller")
+	replicaPromoteCmd.MarkFlagRequired("image")
+	replicaCmd.AddCommand(replicaPromoteCmd)
+	replicaSyncCmd.Flags().String("image", "", "Volume to sync")
+	replicaSyncCmd.MarkFlagRequired("image")
+	replicaCmd.AddCommand(replicaSyncCmd)
+}
@@ -28,3 +28,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&Host, "server", "s", "localhost", "Host for the QMP server")
 
 }
+
+// defaultControllerEndpoint is the CSI socket of the controller, the commands
+// changing the node of the volumes run in the controller pod
+const defaultControllerEndpoint = "unix:///var/lib/csi/sockets/pluginproxy/csi.sock"
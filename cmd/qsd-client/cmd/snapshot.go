@@ -29,6 +29,10 @@ var snapshotCreateCmd = &cobra.Command{
 		if err != nil {
 			log.Fatalf("Error getting the source for the snapshot: %v", err)
 		}
+		internal, err := cmd.Flags().GetBool("internal")
+		if err != nil {
+			log.Fatalf("Error getting the internal flag: %v", err)
+		}
 		// Create client to the QSD grpc server on the node where the volume has to be created
 		var opts []grpc.DialOption
 		opts = append(opts, grpc.WithInsecure())
@@ -44,6 +48,7 @@ var snapshotCreateCmd = &cobra.Command{
 		s := &qsd.Snapshot{
 			ID:             name,
 			SourceVolumeID: source,
+			Internal:       internal,
 		}
 		// Create Snapshot
 		log.Info("create snapshot with the QSD")
@@ -98,12 +103,54 @@ var snapshotDeleteCmd = &cobra.Command{
 	},
 }
 
+var snapshotRevertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert the source volume to a snapshot",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Fatalf("Error getting name for the snapshot: %v", err)
+		}
+		var source string
+		source, err = cmd.Flags().GetString("source")
+		if err != nil {
+			log.Fatalf("Error getting the source for the snapshot: %v", err)
+		}
+		// Create client to the QSD grpc server on the node where the volume is stored
+		var opts []grpc.DialOption
+		opts = append(opts, grpc.WithInsecure())
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", Host, Port), opts...)
+		if err != nil {
+			return fmt.Errorf("Failed to connect to the QSD server:%v", err)
+		}
+		client := qsd.NewQsdServiceClient(conn)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		p := &qsd.RevertParams{
+			VolumeID:   source,
+			SnapshotID: name,
+		}
+		// Revert the volume
+		log.Info("revert volume with the QSD")
+		_, err = client.RevertVolume(ctx, p)
+		if err != nil {
+			return fmt.Errorf("Error for reverting the volume %v", err)
+		}
+
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(snapshotCmd)
 	snapshotCmd.PersistentFlags().String("source", "", "Source of the snapshot")
 	snapshotCmd.PersistentFlags().String("name", "", "Name of the snapshot")
 	snapshotCmd.MarkFlagRequired("name")
 	snapshotCmd.MarkFlagRequired("source")
+	snapshotCreateCmd.Flags().Bool("internal", false, "Store the snapshot in the qcow2 image of the volume")
 	snapshotCmd.AddCommand(snapshotCreateCmd)
 	snapshotCmd.AddCommand(snapshotDeleteCmd)
+	snapshotCmd.AddCommand(snapshotRevertCmd)
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const qsdServiceName = "alicefr.csi.pkg.qsd.QsdService"
+
+// watchHealth periodically checks the qemu-storage-daemon and reports NOT_SERVING
+// through the health service when the daemon or the QMP connection are down
+func watchHealth(ctx context.Context, hs *health.Server, server *qsd.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_SERVING
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		if err := server.Ping(pingCtx); err != nil {
+			if ctx.Err() != nil {
+				cancel()
+				return
+			}
+			log.Warningf("Health check failed: %v", err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		cancel()
+		if status != last {
+			log.Infof("Health status changed to %s", status)
+			last = status
+		}
+		hs.SetServingStatus("", status)
+		hs.SetServingStatus(qsdServiceName, status)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,18 +1,26 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
-	"os/exec"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/alicefr/csi-qsd/pkg/backup"
 	"github.com/alicefr/csi-qsd/pkg/qsd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
 const (
@@ -21,80 +29,136 @@ const (
 )
 
 var (
-	port    = flag.String("port", "", "Port to listen")
-	qsdSock = "/var/run/qsd-qmp.sock"
+	port        = flag.String("port", "", "Port to listen")
+	metricsPort = flag.String("metrics-port", "9090", "Port to expose the prometheus metrics, empty to disable")
+	qmpTimeout  = flag.Duration("qmp-timeout", 30*time.Second, "Timeout of the QMP commands when the request has no deadline")
+	healthCheck = flag.Duration("health-interval", 5*time.Second, "Interval between the health checks of the qemu-storage-daemon")
+	shutdown    = flag.Duration("shutdown-timeout", 60*time.Second, "Deadline for completing the requests in progress at the shutdown")
+	stopTimeout = flag.Duration("stop-timeout", 20*time.Second, "Deadline for saving the state and stopping the qemu-storage-daemon once the requests are completed")
+	stateFile   = flag.String("state-file", "/var/run/qsd/images/qsd-state.json", "File where the state is saved at the shutdown, empty to disable")
+	backupDest  = flag.String("backup-target", "", "Where the backups are stored, file:///path or s3://bucket/prefix, empty to disable")
+	s3Endpoint  = flag.String("s3-endpoint", "", "Endpoint of the S3 object store for the backups")
+	s3Region    = flag.String("s3-region", "us-east-1", "Region of the S3 object store for the backups")
+	nbdPort     = flag.String("nbd-port", "10809", "Port of the NBD server used to migrate the volumes")
+	nbdTLSDir   = flag.String("nbd-tls-dir", "", "Directory with the x509 certificates to encrypt the NBD connections, empty to disable TLS")
+	importDir   = flag.String("import-dir", "", "Directory of the local images that can be imported in the volumes, empty to refuse the local paths")
+	exportDir   = flag.String("export-dir", "", "Directory where the volumes can be exported, empty to disable the exports")
+	rpoInterval = flag.Duration("replication-interval", 30*time.Second, "Interval between the checks of the RPO of the asynchronous replications")
+	qsdSock     = "/var/run/qsd-qmp.sock"
 )
 
-type Result struct {
-	Error  error
-	Output string
-}
-
 func main() {
 	flag.Parse()
 	if *port == "" {
 		log.Fatalf("Specify the port")
 	}
 	log.Infof("Server listening at %s", *port)
-	r := make(chan Result, 1)
-	// Start the qsd in a separate go routine
-	go func() {
-		cmd := exec.Command("qemu-storage-daemon", "--pidfile",
-			qsdPidfile,
-			"--chardev",
-			fmt.Sprintf("socket,server=on,path=%s,id=chardev0,wait=off", qsdSock),
-			"--monitor",
-			"chardev=chardev0")
-		out, err := cmd.CombinedOutput()
-		r <- Result{Error: err, Output: string(out)}
-
-	}()
-	waitForQSDPidfile := make(chan bool)
-	go func() {
-		for {
-			if _, err := os.Stat(qsdPidfile); !os.IsNotExist(err) {
-				waitForQSDPidfile <- true
-			}
-			time.Sleep(50 * time.Millisecond)
-		}
-
-	}()
-
-	select {
-	case <-waitForQSDPidfile:
-		log.Infof("QSD started...")
-	case result := <-r:
-		// the qsd terminated check the error and output
-		log.Fatalf("QSD terminated output: %s err: %v", result.Output, result.Error)
-	case <-time.After(time.Second * timeout):
-		log.Fatalf("Timeout in waiting for the qsd to start")
-
+	qsd.CommandTimeout = *qmpTimeout
+	// Start the qsd under the supervisor
+	sup := newSupervisor(qsdPidfile, qsdSock)
+	if err := sup.start(); err != nil {
+		log.Fatalf("%v", err)
 	}
 	// List for grpc command
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%s", *port))
 	if err != nil {
 		log.Fatalln(err)
 	}
-	srv := grpc.NewServer()
 	qmpServer, err := qsd.NewServer(qsdSock)
 	if err != nil {
 		log.Fatalf("Starting connection with the QMP: %v", err)
 	}
+	qmpServer.SetNBD(*nbdPort, *nbdTLSDir)
+	qmpServer.SetImageDirs(*importDir, *exportDir)
+	if *backupDest != "" {
+		// The credentials are read from the environment to not leak them in the command line
+		u, err := backup.NewUploader(backup.Config{
+			Target:      *backupDest,
+			S3Endpoint:  *s3Endpoint,
+			S3Region:    *s3Region,
+			S3AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			S3SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		})
+		if err != nil {
+			log.Fatalf("Configuring the backups: %v", err)
+		}
+		qmpServer.SetUploader(u)
+	}
+	if *stateFile != "" {
+		if err := qmpServer.LoadState(context.Background(), *stateFile); err != nil {
+			log.Errorf("Failed restoring the state: %v", err)
+			qmpServer.SetDegraded(fmt.Sprintf("failed restoring the state: %v", err))
+		}
+	}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(qmpServer.UnaryInterceptor()),
+		grpc.StreamInterceptor(qmpServer.StreamInterceptor()),
+		// Accept the keepalive pings of the pooled connections of the driver
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}))
 	qsd.RegisterQsdServiceServer(srv, qmpServer)
+	sup.server = qmpServer
+	go sup.run()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchHealth(ctx, healthServer, qmpServer, *healthCheck)
+	go qmpServer.RunReplications(ctx, *rpoInterval)
 	serError := make(chan error, 1)
 	go func() {
 		serError <- srv.Serve(lis)
 	}()
+	if *metricsPort != "" {
+		if err := qsd.RegisterMetrics(prometheus.DefaultRegisterer, qmpServer); err != nil {
+			log.Fatalf("Failed registering the metrics: %v", err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		log.Infof("Metrics exposed at %s", *metricsPort)
+		go func() {
+			serError <- http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", *metricsPort), mux)
+		}()
+	}
 	ch := make(chan os.Signal, 10)
 	signal.Notify(ch, syscall.SIGTERM, syscall.SIGHUP)
 	select {
 	case <-ch:
 		log.Info("Gracefully terminate")
-		srv.Stop()
-	case result := <-r:
-		// the qsd terminated check the error and output
-		log.Fatalf("QSD terminated output: %s err: %v", result.Output, result.Error)
+		gracefulShutdown(srv, healthServer, qmpServer, sup)
 	case result := <-serError:
+		sup.stop()
 		log.Fatalf("GRPC server failed: %v", result)
 	}
 }
+
+// gracefulShutdown completes the requests in progress, stops the daemon cleanly
+// and kills it if the stop timeout expires. The daemon has its own deadline to be
+// stopped also when the requests took the whole shutdown timeout.
+func gracefulShutdown(srv *grpc.Server, healthServer *health.Server, qmpServer *qsd.Server, sup *supervisor) {
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdown)
+	defer cancel()
+	healthServer.Shutdown()
+	qmpServer.Drain()
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+	sup.disableRestart()
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), *stopTimeout)
+	defer stopCancel()
+	if err := qmpServer.Shutdown(stopCtx, *stateFile); err != nil {
+		log.Errorf("Shutdown of the QSD: %v", err)
+	}
+	if err := sup.wait(stopCtx); err != nil {
+		log.Errorf("Waiting for the QSD: %v", err)
+	}
+}
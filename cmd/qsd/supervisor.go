@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	minBackoff = 1 * time.Second
+	maxBackoff = 1 * time.Minute
+	// After running for stableRun the daemon is considered healthy and the backoff is reset
+	stableRun = 5 * time.Minute
+)
+
+// supervisor starts the qemu-storage-daemon and restarts it when it terminates
+type supervisor struct {
+	pidfile string
+	sock    string
+	server  *qsd.Server
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+	// exited is closed when the daemon terminates, after exitErr is set
+	exited  chan struct{}
+	exitErr error
+	started time.Time
+	stopped bool
+}
+
+func newSupervisor(pidfile, sock string) *supervisor {
+	return &supervisor{
+		pidfile: pidfile,
+		sock:    sock,
+	}
+}
+
+// start launches the qemu-storage-daemon and waits until it has written the pidfile
+func (s *supervisor) start() error {
+	// Remove the leftovers of a previous instance of the daemon
+	for _, f := range []string{s.pidfile, s.sock} {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	cmd := exec.Command("qemu-storage-daemon", "--pidfile",
+		s.pidfile,
+		"--chardev",
+		fmt.Sprintf("socket,server=on,path=%s,id=chardev0,wait=off", s.sock),
+		"--monitor",
+		"chardev=chardev0")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	exited := make(chan struct{})
+	if err := cmd.Start(); err != nil {
+		s.mu.Lock()
+		s.exited = exited
+		s.exitErr = err
+		s.mu.Unlock()
+		close(exited)
+		return fmt.Errorf("Failed starting the QSD: %v", err)
+	}
+	s.mu.Lock()
+	s.cmd = cmd
+	s.exited = exited
+	s.exitErr = nil
+	s.started = time.Now()
+	s.mu.Unlock()
+	go func() {
+		err := cmd.Wait()
+		s.mu.Lock()
+		s.exitErr = err
+		s.mu.Unlock()
+		close(exited)
+	}()
+
+	timeout := time.After(time.Second * timeout)
+	for {
+		if _, err := os.Stat(s.pidfile); err == nil {
+			log.Infof("QSD started...")
+			return nil
+		}
+		select {
+		case <-exited:
+			return fmt.Errorf("QSD terminated: %v", s.exitError())
+		case <-timeout:
+			s.kill()
+			return fmt.Errorf("Timeout in waiting for the qsd to start")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (s *supervisor) kill() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cmd != nil && s.cmd.Process != nil {
+		s.cmd.Process.Kill()
+	}
+}
+
+// exitError returns the termination error of the last instance of the daemon
+func (s *supervisor) exitError() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exitErr
+}
+
+func (s *supervisor) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// disableRestart prevents the supervisor from restarting the daemon when it terminates
+func (s *supervisor) disableRestart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+}
+
+// stop terminates the qemu-storage-daemon without restarting it
+func (s *supervisor) stop() {
+	s.disableRestart()
+	s.kill()
+}
+
+// wait waits for the qemu-storage-daemon written in the pidfile to terminate and
+// kills it when the context expires
+func (s *supervisor) wait(ctx context.Context) error {
+	s.mu.Lock()
+	exited := s.exited
+	s.mu.Unlock()
+	if pid, err := ioutil.ReadFile(s.pidfile); err == nil {
+		log.Infof("Waiting for the QSD with pid %s to terminate", strings.TrimSpace(string(pid)))
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-ctx.Done():
+		log.Warningf("The QSD did not terminate in time, kill it")
+		s.kill()
+		return ctx.Err()
+	}
+}
+
+// waitExited blocks until the last instance of the daemon has terminated
+func (s *supervisor) waitExited() {
+	s.mu.Lock()
+	exited := s.exited
+	s.mu.Unlock()
+	<-exited
+}
+
+// run watches the qemu-storage-daemon and restarts it with an exponential backoff
+// every time it terminates. Once the daemon is up again, the server reconnects and
+// replays the block graph and the exports.
+func (s *supervisor) run() {
+	backoff := minBackoff
+	for {
+		s.mu.Lock()
+		exited, started := s.exited, s.started
+		s.mu.Unlock()
+		<-exited
+		if s.isStopped() {
+			return
+		}
+		err := s.exitError()
+		log.Errorf("QSD terminated: %v", err)
+		s.server.SetDegraded(fmt.Sprintf("qemu-storage-daemon terminated: %v", err))
+		if time.Since(started) > stableRun {
+			backoff = minBackoff
+		}
+		for {
+			log.Infof("Restart the QSD in %v", backoff)
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			if s.isStopped() {
+				return
+			}
+			if err := s.start(); err != nil {
+				log.Errorf("Failed restarting the QSD: %v", err)
+				s.server.SetDegraded(err.Error())
+				// Wait for the termination of the failed instance
+				s.waitExited()
+				continue
+			}
+			if err := s.server.Recover(); err != nil {
+				log.Errorf("Failed recovering the QSD state: %v", err)
+				s.server.SetDegraded(err.Error())
+				s.kill()
+				s.waitExited()
+				continue
+			}
+			log.Infof("QSD recovered")
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/driver"
+	"github.com/alicefr/csi-qsd/pkg/schedule"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	kubeconfig = flag.String("kubeconfig", "", "Path of the kubeconfig, empty when running in the cluster")
+	interval   = flag.Duration("interval", time.Minute, "Interval between the checks of the schedules")
+	driverName = flag.String("driver-name", driver.DefaultDriverName, "Name of the driver provisioning the volumes to snapshot")
+)
+
+func main() {
+	flag.Parse()
+	var config *rest.Config
+	var err error
+	if *kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		log.Fatalf("Failed getting the configuration of the cluster: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("Failed creating the Kubernetes client: %v", err)
+	}
+	dynClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("Failed creating the dynamic client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	s := schedule.NewScheduler(client, dynClient, *interval, *driverName)
+	if err := s.Run(ctx); err != nil {
+		log.Fatalf("Scheduler failed: %v", err)
+	}
+}
@@ -3,9 +3,13 @@ TAG=latest
 IMAGE_DRIVER_NAME="qsd/driver"
 IMAGE_QSD_NAME="qsd/qsd"
 IMAGE_METADATA_NAME="qsd/metadata"
+IMAGE_POPULATOR_NAME="qsd/populator"
+IMAGE_SCHEDULER_NAME="qsd/scheduler"
 IMAGE_DRIVER="${IMAGE_DRIVER_NAME}:${TAG}"
 IMAGE_QSD="${IMAGE_QSD_NAME}:${TAG}"
 IMAGE_METADATA="${IMAGE_METADATA_NAME}:${TAG}"
+IMAGE_POPULATOR="${IMAGE_POPULATOR_NAME}:${TAG}"
+IMAGE_SCHEDULER="${IMAGE_SCHEDULER_NAME}:${TAG}"
 CLUSTER=k8s-qsd
 kubectl delete -f deployment/driver.yaml
 docker exec -ti k8s-qsd-control-plane crictl rmi ${IMAGE_DRIVER}
@@ -15,10 +19,14 @@ set -ex
 kind load docker-image --name ${CLUSTER}  ${IMAGE_DRIVER}
 kind load docker-image --name ${CLUSTER} ${IMAGE_QSD}
 kind load docker-image --name ${CLUSTER} ${IMAGE_METADATA}
+kind load docker-image --name ${CLUSTER} ${IMAGE_POPULATOR}
+kind load docker-image --name ${CLUSTER} ${IMAGE_SCHEDULER}
 kubectl apply -f deployment/namespace.yaml
 kubectl apply -f deployment/qsd-ds.yaml
 kubectl apply -f deployment/driver.yaml
 kubectl apply -f deployment/snapshotclass.yaml
+kubectl apply -f deployment/populator.yaml
+kubectl apply -f deployment/scheduler.yaml
 
 # Set context
 kubectl config set-context csi --namespace csi-qsd --user kind-k8s-qsd --cluster kind-k8s-qsd
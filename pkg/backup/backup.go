@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Uploader stores the backup images and their manifests outside of the node
+type Uploader interface {
+	// Upload copies the local file to the key
+	Upload(ctx context.Context, key, file string) error
+	// Download copies the object stored at key in the local file
+	Download(ctx context.Context, key, file string) error
+}
+
+// Manifest describes a backup. Incremental backups only contain the clusters
+// changed since the Parent backup.
+type Manifest struct {
+	ID          string    `json:"id"`
+	Parent      string    `json:"parent,omitempty"`
+	Volume      string    `json:"volume"`
+	VirtualSize int64     `json:"virtualSize"`
+	Created     time.Time `json:"created"`
+}
+
+// DataKey returns the key of the qcow2 image of the backup
+func DataKey(volume, id string) string {
+	return path.Join(volume, id+".qcow2")
+}
+
+// ManifestKey returns the key of the manifest of the backup
+func ManifestKey(volume, id string) string {
+	return path.Join(volume, id+".json")
+}
+
+// PutManifest uploads the manifest of the backup
+func PutManifest(ctx context.Context, u Uploader, m *Manifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile("", "manifest")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return u.Upload(ctx, ManifestKey(m.Volume, m.ID), f.Name())
+}
+
+// GetManifest downloads the manifest of the backup
+func GetManifest(ctx context.Context, u Uploader, volume, id string) (*Manifest, error) {
+	f, err := ioutil.TempFile("", "manifest")
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	if err := u.Download(ctx, ManifestKey(volume, id), f.Name()); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed parsing the manifest of the backup %s: %v", id, err)
+	}
+	return &m, nil
+}
+
+// Chain returns the manifests from the full backup to the backup id
+func Chain(ctx context.Context, u Uploader, volume, id string) ([]*Manifest, error) {
+	var chain []*Manifest
+	seen := make(map[string]bool)
+	for id != "" {
+		if seen[id] {
+			return nil, fmt.Errorf("loop in the backup chain at %s", id)
+		}
+		seen[id] = true
+		m, err := GetManifest(ctx, u, volume, id)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]*Manifest{m}, chain...)
+		id = m.Parent
+	}
+	return chain, nil
+}
+
+// Config contains the parameters of the backup targets
+type Config struct {
+	// Target is either file:///path or s3://bucket/prefix
+	Target      string
+	S3Endpoint  string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// NewUploader creates the uploader for the target of the configuration
+func NewUploader(c Config) (Uploader, error) {
+	u, err := url.Parse(c.Target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup target %s: %v", c.Target, err)
+	}
+	switch u.Scheme {
+	case "file":
+		return &FileUploader{Dir: u.Path}, nil
+	case "s3":
+		if c.S3Endpoint == "" {
+			return nil, fmt.Errorf("the S3 endpoint is required for the target %s", c.Target)
+		}
+		region := c.S3Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		return &S3Uploader{
+			Endpoint:  strings.TrimSuffix(c.S3Endpoint, "/"),
+			Region:    region,
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			AccessKey: c.S3AccessKey,
+			SecretKey: c.S3SecretKey,
+		}, nil
+	}
+	return nil, fmt.Errorf("backup target scheme %s not supported", u.Scheme)
+}
+
+// FileUploader copies the backups in a local directory, for example a NFS mount
+type FileUploader struct {
+	Dir string
+}
+
+func (f *FileUploader) Upload(_ context.Context, key, file string) error {
+	dst := filepath.Join(f.Dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	// Copy in a temporary file to not leave partial backups
+	if err := copyFile(file, dst+".tmp"); err != nil {
+		return err
+	}
+	return os.Rename(dst+".tmp", dst)
+}
+
+func (f *FileUploader) Download(_ context.Context, key, file string) error {
+	return copyFile(filepath.Join(f.Dir, filepath.FromSlash(key)), file)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package csiext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// VolumeControllerClient is the client API for VolumeController service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VolumeControllerClient interface {
+	// PromoteReplica makes the replica of a volume the primary after the loss of the
+	// primary node
+	PromoteReplica(ctx context.Context, in *PromoteReplicaRequest, opts ...grpc.CallOption) (*PromoteReplicaResponse, error)
+	// MigrateVolume copies the volume on another node and moves its PV there. The
+	// volume must not be in use during the copy.
+	MigrateVolume(ctx context.Context, in *MigrateVolumeRequest, opts ...grpc.CallOption) (*MigrateVolumeResponse, error)
+}
+
+type volumeControllerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVolumeControllerClient(cc grpc.ClientConnInterface) VolumeControllerClient {
+	return &volumeControllerClient{cc}
+}
+
+func (c *volumeControllerClient) PromoteReplica(ctx context.Context, in *PromoteReplicaRequest, opts ...grpc.CallOption) (*PromoteReplicaResponse, error) {
+	out := new(PromoteReplicaResponse)
+	err := c.cc.Invoke(ctx, "/qsd.csi.v1.VolumeController/PromoteReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeControllerClient) MigrateVolume(ctx context.Context, in *MigrateVolumeRequest, opts ...grpc.CallOption) (*MigrateVolumeResponse, error) {
+	out := new(MigrateVolumeResponse)
+	err := c.cc.Invoke(ctx, "/qsd.csi.v1.VolumeController/MigrateVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VolumeControllerServer is the server API for VolumeController service.
+// All implementations must embed UnimplementedVolumeControllerServer
+// for forward compatibility
+type VolumeControllerServer interface {
+	// PromoteReplica makes the replica of a volume the primary after the loss of the
+	// primary node
+	PromoteReplica(context.Context, *PromoteReplicaRequest) (*PromoteReplicaResponse, error)
+	// MigrateVolume copies the volume on another node and moves its PV there. The
+	// volume must not be in use during the copy.
+	MigrateVolume(context.Context, *MigrateVolumeRequest) (*MigrateVolumeResponse, error)
+	mustEmbedUnimplementedVolumeControllerServer()
+}
+
+// UnimplementedVolumeControllerServer must be embedded to have forward compatible implementations.
+type UnimplementedVolumeControllerServer struct {
+}
+
+func (UnimplementedVolumeControllerServer) PromoteReplica(context.Context, *PromoteReplicaRequest) (*PromoteReplicaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteReplica not implemented")
+}
+func (UnimplementedVolumeControllerServer) MigrateVolume(context.Context, *MigrateVolumeRequest) (*MigrateVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateVolume not implemented")
+}
+func (UnimplementedVolumeControllerServer) mustEmbedUnimplementedVolumeControllerServer() {}
+
+// UnsafeVolumeControllerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VolumeControllerServer will
+// result in compilation errors.
+type UnsafeVolumeControllerServer interface {
+	mustEmbedUnimplementedVolumeControllerServer()
+}
+
+func RegisterVolumeControllerServer(s grpc.ServiceRegistrar, srv VolumeControllerServer) {
+	s.RegisterService(&VolumeController_ServiceDesc, srv)
+}
+
+func _VolumeController_PromoteReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteReplicaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeControllerServer).PromoteReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/qsd.csi.v1.VolumeController/PromoteReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeControllerServer).PromoteReplica(ctx, req.(*PromoteReplicaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeController_MigrateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeControllerServer).MigrateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/qsd.csi.v1.VolumeController/MigrateVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeControllerServer).MigrateVolume(ctx, req.(*MigrateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VolumeController_ServiceDesc is the grpc.ServiceDesc for VolumeController service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VolumeController_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "qsd.csi.v1.VolumeController",
+	HandlerType: (*VolumeControllerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PromoteReplica",
+			Handler:    _VolumeController_PromoteReplica_Handler,
+		},
+		{
+			MethodName: "MigrateVolume",
+			Handler:    _VolumeController_MigrateVolume_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/csiext/volume.proto",
+}
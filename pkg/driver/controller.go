@@ -10,11 +10,18 @@ import (
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// Parameters passed by the external-provisioner with --extra-create-metadata
+	paramPVCName      = "csi.storage.k8s.io/pvc/name"
+	paramPVCNamespace = "csi.storage.k8s.io/pvc/namespace"
+	// paramSharedWritable allows multiple writable publishes of the same volume
+	paramSharedWritable = "sharedWritable"
+)
+
 // createImageID cuts the ID it removes the pvc- prefix and takes the first 8 chars
 func createImageID(ID string) string {
 	return strings.TrimPrefix(ID, "pvc-")[:8]
@@ -72,54 +79,95 @@ func (d *Driver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 
 	}
 
-	// TODO smart scheduling we need already to know where the volume has to be created
-	// HACK hard code the node just for PoC
+	export, err := exportOptions(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	io, err := ioOptions(req.GetParameters(), export)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := snapshotMode(req.GetParameters(), snapshotModeExternal)
+	if err != nil {
+		return nil, err
+	}
+	replication, rpo, err := replicationMode(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	// The volume is created on the node selected by the CO
 	v, ok := d.storage[volumeName]
 	if !ok {
 		v = Volume{
-			id:   volumeName,
-			size: size.RequiredBytes,
-			node: "k8s-qsd-control-plane",
+			id:           volumeName,
+			size:         size.RequiredBytes,
+			node:         selectNode(req.GetAccessibilityRequirements()),
+			snapshotMode: mode,
+		}
+		if replication != "" {
+			if v.replica, err = selectReplicaNode(req.GetParameters(), req.GetAccessibilityRequirements(), v.node); err != nil {
+				return nil, err
+			}
+			v.replicationMode, v.rpo = replication, rpo
 		}
 		d.storage[volumeName] = v
 	}
 	image := &qsd.Image{
-		ID:         volumeName,
-		Size:       v.size,
-		FromVolume: source,
-	}
-	// Create client to the QSD grpc server on the node where the volume has to be created
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", v.node, d.port), opts...)
+		ID:           volumeName,
+		Size:         v.size,
+		FromVolume:   source,
+		PVCName:      req.GetParameters()[paramPVCName],
+		PVCNamespace: req.GetParameters()[paramPVCNamespace],
+		Export:       export,
+		IO:           io,
+	}
+	// Get the client to the QSD grpc server on the node where the volume has to be created
+	client, err := d.qsdClient(ctx, v.node)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to connect to the QSD server for node %s:%v", v.node, err)
+		return nil, err
 	}
-	client := qsd.NewQsdServiceClient(conn)
-	defer conn.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	// Create Volume
-	log.Info("create backend image with the QSD")
-	var r *qsd.Response
-	r, err = client.CreateVolume(ctx, image)
+	imageSource, imageFormat, err := d.imageSource(ctx, req.GetParameters())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Error for creating the volume %v", err)
-	}
-	if !r.Success {
-		return nil, status.Error(codes.Internal, r.Message)
+		return nil, err
 	}
-	// Create exporter
-	log.Info("create exporter with the QSD")
-	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	r, err = client.ExposeVhostUser(ctx, image)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Error for creating the exporter %v", err)
+	var r *qsd.Response
+	if source == "" && imageSource != "" {
+		log.Infof("create backend image from %s", imageSource)
+		if err := d.importImage(ctx, v.node, imageSource, imageFormat, image); err != nil {
+			return nil, err
+		}
+	} else if srcNode, ok := d.sourceNode(source); ok && srcNode != v.node {
+		if d.snapshots[source].internal {
+			return nil, status.Errorf(codes.InvalidArgument, "The internal snapshot %s can be cloned only on the node %s", source, srcNode)
+		}
+		// The source is on another node, copy it in a standalone image
+		log.Infof("create backend image from node %s", srcNode)
+		image.FromVolume = ""
+		if err := d.copyFromNode(ctx, srcNode, v.node, source, image); err != nil {
+			return nil, err
+		}
+	} else {
+		callCtx, cancel := callContext(ctx)
+		defer cancel()
+		// Create Volume
+		log.Info("create backend image with the QSD")
+		r, err = client.CreateVolume(callCtx, image)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error for creating the volume %v", err)
+		}
+		if !r.Success {
+			return nil, status.Error(codes.Internal, r.Message)
+		}
 	}
-	if !r.Success {
-		return nil, status.Error(codes.Internal, r.Message)
+	// The replica can't be used before the promotion, the volume is accessible only
+	// from the primary
+	if v.replica != "" {
+		log.Infof("replicate the volume on %s", v.replica)
+		if err := d.replicate(ctx, v, image); err != nil {
+			return nil, err
+		}
 	}
 
 	resp := &csi.CreateVolumeResponse{
@@ -127,8 +175,15 @@ func (d *Driver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest)
 			VolumeId:      volumeName,
 			CapacityBytes: size.RequiredBytes,
 			ContentSource: contentSourceResp,
+			AccessibleTopology: []*csi.Topology{
+				{Segments: map[string]string{TopologyKey: v.node}},
+			},
 		},
 	}
+	if sw, ok := req.GetParameters()[paramSharedWritable]; ok {
+		resp.Volume.VolumeContext = map[string]string{paramSharedWritable: sw}
+	}
+	resp.Volume.VolumeContext = replicaContext(v, resp.Volume.VolumeContext)
 	log.Info("response", "volume was created")
 	return resp, nil
 }
@@ -148,29 +203,30 @@ func (d *Driver) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest)
 		log.Errorf("Failed to delete volume %s: because not found", req.VolumeId)
 		return &csi.DeleteVolumeResponse{}, nil
 	}
-	// Create client to the QSD grpc server on the node where the volume has to be created
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", v.node, d.port), opts...)
+	// Get the client to the QSD grpc server on the node where the volume has to be created
+	if v.replica != "" {
+		if err := d.deleteReplica(ctx, v); err != nil {
+			return nil, err
+		}
+	}
+	client, err := d.qsdClient(ctx, v.node)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to connect to the QSD server for node %s:%v", v.node, err)
+		return nil, err
 	}
-	client := qsd.NewQsdServiceClient(conn)
-	defer conn.Close()
 	image := &qsd.Image{
 		ID: v.id,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	callCtx, cancel := callContext(ctx)
 	defer cancel()
 	// Remove exporter
-	_, err = client.DeleteExporter(ctx, image)
+	_, err = client.DeleteExporter(callCtx, image)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error for creating the exporter %v", err)
 	}
 	// Remove Volume
-	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	callCtx, cancel = callContext(ctx)
 	defer cancel()
-	_, err = client.DeleteVolume(ctx, image)
+	_, err = client.DeleteVolume(callCtx, image)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error for creating the volume %v", err)
 	}
@@ -223,15 +279,7 @@ func (d *Driver) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequ
 
 	if t, ok := d.snapshots[id]; ok {
 		log.Info("Snapshot already created")
-		return &csi.CreateSnapshotResponse{
-			Snapshot: &csi.Snapshot{
-				SnapshotId:     id,
-				SourceVolumeId: imageID,
-				ReadyToUse:     true,
-				SizeBytes:      t.size,
-			},
-		}, nil
-
+		return snapshotResponse(id, t)
 	}
 	// Retrieve base image
 	source, ok := d.storage[imageID]
@@ -244,34 +292,56 @@ func (d *Driver) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequ
 		source: imageID,
 		size:   source.size,
 	}
+	mode, err := snapshotMode(req.GetParameters(), source.snapshotMode)
+	if err != nil {
+		return nil, err
+	}
+	s.internal = mode == snapshotModeInternal
+	hooks, err := parseHooks(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Info("create snapshot is called")
-	// Create client to the QSD grpc server on the node where the volume has to be created
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", s.node, d.port), opts...)
+	// Get the client to the QSD grpc server on the node where the volume has to be created
+	client, err := d.qsdClient(ctx, s.node)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to connect to the QSD server for node %s:%v", source.node, err)
+		return nil, err
 	}
-	client := qsd.NewQsdServiceClient(conn)
-	defer conn.Close()
 
 	image := &qsd.Snapshot{
 		ID:             id,
 		SourceVolumeID: imageID,
+		Internal:       s.internal,
+	}
+	// Quiesce the applications, the post hooks run also if the pre hooks or the
+	// snapshot failed to unfreeze the partially frozen applications
+	if hooks != nil {
+		defer d.postHooks(hooks, imageID, req.GetParameters())
+		if err := d.runHooks(ctx, hooks, "pre", imageID, req.GetParameters()); err != nil {
+			return nil, err
+		}
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	callCtx, cancel := callContext(ctx)
 	defer cancel()
 	// Create snapshot
 	log.Info("create snapshot with the QSD")
-	_, err = client.CreateSnapshot(ctx, image)
+	resp, err := client.CreateSnapshot(callCtx, image)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error in creating the snapshot %v", err)
 	}
+	s.size = resp.RestoreSize
+	s.created = time.Unix(0, resp.CreationTime)
 	// Snapshot successfully created store it
 	d.snapshots[id] = s
-	log.Info("successfully add snapshot %v", s)
-	tstamp, err := ptypes.TimestampProto(time.Now())
+	log.Infof("successfully add snapshot %v allocating %d bytes", s, resp.AllocatedBytes)
+	return snapshotResponse(id, s)
+}
+
+// snapshotResponse returns the snapshot with the size and the creation time
+// reported by the qsd server when the snapshot was taken
+func snapshotResponse(id string, s Snapshot) (*csi.CreateSnapshotResponse, error) {
+	tstamp, err := ptypes.TimestampProto(s.created)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't convert protobuf timestamp to go time.Time: %s",
 			err.Error())
@@ -279,9 +349,9 @@ func (d *Driver) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequ
 	return &csi.CreateSnapshotResponse{
 		Snapshot: &csi.Snapshot{
 			SnapshotId:     id,
-			SourceVolumeId: imageID,
+			SourceVolumeId: s.source,
 			ReadyToUse:     true,
-			SizeBytes:      source.size,
+			SizeBytes:      s.size,
 			CreationTime:   tstamp,
 		},
 	}, nil
@@ -301,24 +371,20 @@ func (d *Driver) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequ
 		log.Errorf("Failed to delete volume %s: because not found", id)
 		return &csi.DeleteSnapshotResponse{}, nil
 	}
-	// Create client to the QSD grpc server on the node where the volume has to be created
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%s", s.node, d.port), opts...)
+	// Get the client to the QSD grpc server on the node where the volume has to be created
+	client, err := d.qsdClient(ctx, s.node)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to connect to the QSD server for node %s:%v", s.node, err)
+		return nil, err
 	}
-	client := qsd.NewQsdServiceClient(conn)
-	defer conn.Close()
 	image := &qsd.Snapshot{
 		ID:             id,
 		SourceVolumeID: s.source,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	callCtx, cancel := callContext(ctx)
 	defer cancel()
 	// delete snapshot
 	log.Info("delete snapshot with the QSD")
-	_, err = client.DeleteSnapshot(ctx, image)
+	_, err = client.DeleteSnapshot(callCtx, image)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error for creating the exporter %v", err)
 	}
@@ -9,10 +9,19 @@ import (
 	"path"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/metadata"
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -20,6 +29,13 @@ const (
 	version           = "0.0.0"
 )
 
+const (
+	// TopologyKey is the segment with the node where the volume is stored
+	TopologyKey = "topology.qsd.csi.com/node"
+	// defaultNode is used when the CO doesn't pass any topology requirement
+	defaultNode = "k8s-qsd-control-plane"
+)
+
 const (
 	SocketDir = "/var/run/qsd/sockets"
 	vhostSock = "vhost.sock"
@@ -29,18 +45,31 @@ type Volume struct {
 	id   string
 	size int64
 	node string
+	// snapshotMode is the default mode of the snapshots of the volume
+	snapshotMode string
+	// replica is the node with the replica of the volume, the asynchronous
+	// replications copy the writes every rpo
+	replica         string
+	replicationMode string
+	rpo             time.Duration
 }
 
 type Snapshot struct {
-	baseID string
-	node   string
-	source string
-	size   int64
+	baseID  string
+	node    string
+	source  string
+	size    int64
+	created time.Time
+	// internal is set for the snapshots stored in the qcow2 image of the volume
+	internal bool
 }
 
 type Driver struct {
 	csi.UnimplementedControllerServer
 	csi.UnimplementedNodeServer
+	csiext.UnimplementedGroupControllerServer
+	csiext.UnimplementedSnapshotMetadataServer
+	csiext.UnimplementedVolumeControllerServer
 	name      string
 	version   string
 	endpoint  string
@@ -50,27 +79,76 @@ type Driver struct {
 	storage   map[string]Volume
 	snapshots map[string]Snapshot
 
+	// groupSnapshots contains the snapshots of the volume groups
+	groupSnapshots map[string]GroupSnapshot
+
+	// kube is nil when the driver runs outside of the cluster
+	kube kubernetes.Interface
+	// kubeConfig is used to exec the snapshot hooks in the pods
+	kubeConfig *rest.Config
+	recorder   record.EventRecorder
+
+	// pool of the connections to the qsd servers
+	pool     *clientPool
+	poolOnce sync.Once
+
+	// transfers contains the copies of volumes between nodes
+	transfersMu sync.Mutex
+	transfers   map[string]*transfer
+
 	srv *grpc.Server
 	log *logrus.Entry
 
 	nodeId string
 }
 
-func NewDriver(endpoint, driverName, nodeId, port string) (*Driver, error) {
+// NewDriver creates the driver. When qsdSelector is set, the qsd servers are
+// discovered through the pods matching the selector in qsdNamespace, otherwise
+// the node names need to resolve as hostnames.
+func NewDriver(endpoint, driverName, nodeId, port, qsdNamespace, qsdSelector string) (*Driver, error) {
 	log := logrus.New().WithFields(logrus.Fields{
 		"endpoint": endpoint,
 		"node-id":  nodeId,
 	})
+	var resolver nodeResolver = hostnameResolver{}
+	var kube kubernetes.Interface
+	var kubeConfig *rest.Config
+	var recorder record.EventRecorder
+	client, err := metadata.NewK8sClientFromCluster()
+	if err != nil {
+		log.Warnf("Cannot connect to the Kubernetes API, the qsd servers are resolved by node name and the PVC annotations are ignored: %v", err)
+	} else {
+		kube = client
+		if kubeConfig, err = rest.InClusterConfig(); err != nil {
+			return nil, err
+		}
+		broadcaster := record.NewBroadcaster()
+		broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+		recorder = broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "qsd-driver"})
+		if qsdSelector != "" {
+			resolver = &podResolver{
+				client:    client,
+				namespace: qsdNamespace,
+				selector:  qsdSelector,
+			}
+		}
+	}
 	return &Driver{
-		version:   version,
-		endpoint:  endpoint,
-		storage:   make(map[string]Volume),
-		snapshots: make(map[string]Snapshot),
-		name:      driverName,
-		log:       log,
-		ready:     true,
-		nodeId:    nodeId,
-		port:      port,
+		kube:           kube,
+		kubeConfig:     kubeConfig,
+		recorder:       recorder,
+		pool:           newClientPool(port, resolver, log),
+		version:        version,
+		endpoint:       endpoint,
+		storage:        make(map[string]Volume),
+		snapshots:      make(map[string]Snapshot),
+		groupSnapshots: make(map[string]GroupSnapshot),
+		transfers:      make(map[string]*transfer),
+		name:           driverName,
+		log:            log,
+		ready:          true,
+		nodeId:         nodeId,
+		port:           port,
 	}, nil
 }
 
@@ -135,6 +213,9 @@ func (d *Driver) Run(ctx context.Context) error {
 	csi.RegisterIdentityServer(d.srv, d)
 	csi.RegisterControllerServer(d.srv, d)
 	csi.RegisterNodeServer(d.srv, d)
+	csiext.RegisterGroupControllerServer(d.srv, d)
+	csiext.RegisterSnapshotMetadataServer(d.srv, d)
+	csiext.RegisterVolumeControllerServer(d.srv, d)
 
 	d.log.WithField("addr", addr).Info("server started")
 	return d.srv.Serve(listener)
@@ -144,4 +225,7 @@ func (d *Driver) Run(ctx context.Context) error {
 func (d *Driver) Stop() {
 	d.log.Info("server stopped")
 	d.srv.Stop()
+	if d.pool != nil {
+		d.pool.close()
+	}
 }
@@ -30,7 +30,12 @@ func (g *idGenerator) GenerateInvalidNodeID() string {
 	return "not-an-integer"
 }
 
+// TestDriverSuite runs the CSI sanity tests, the driver needs the qsd servers of the
+// nodes and the test is skipped unless QSD_SANITY is set
 func TestDriverSuite(t *testing.T) {
+	if os.Getenv("QSD_SANITY") == "" {
+		t.Skip("QSD_SANITY not set, skipping the CSI sanity tests")
+	}
 	socket := "/tmp/csi.sock"
 	endpoint := "unix://" + socket
 	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,200 @@
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// groupControllerService is the GROUP_CONTROLLER_SERVICE plugin capability, missing
+// in the vendored spec
+const groupControllerService = csi.PluginCapability_Service_Type(3)
+
+type GroupSnapshot struct {
+	node      string
+	snapshots []string
+	created   time.Time
+}
+
+// groupMemberID returns the ID of the snapshot of the volume in the group. The
+// beginning of the ID is used by the qsd server to name the image and needs to differ
+// between the volumes.
+func groupMemberID(group, volumeID string) string {
+	return fmt.Sprintf("snapshot-%x", sha256.Sum256([]byte(group+"/"+volumeID)))[:45]
+}
+
+func (d *Driver) GroupControllerGetCapabilities(ctx context.Context, req *csiext.GroupControllerGetCapabilitiesRequest) (*csiext.GroupControllerGetCapabilitiesResponse, error) {
+	return &csiext.GroupControllerGetCapabilitiesResponse{
+		Capabilities: []*csiext.GroupControllerServiceCapability{
+			{
+				Type: &csiext.GroupControllerServiceCapability_Rpc{
+					Rpc: &csiext.GroupControllerServiceCapability_RPC{
+						Type: csiext.GroupControllerServiceCapability_RPC_CREATE_DELETE_GET_VOLUME_GROUP_SNAPSHOT,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// CreateVolumeGroupSnapshot snapshots together the volumes, all the volumes need to
+// be on the same node
+func (d *Driver) CreateVolumeGroupSnapshot(ctx context.Context, req *csiext.CreateVolumeGroupSnapshotRequest) (*csiext.CreateVolumeGroupSnapshotResponse, error) {
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "CreateVolumeGroupSnapshot Name must be provided")
+	}
+	if len(req.GetSourceVolumeIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CreateVolumeGroupSnapshot Source Volume IDs must be provided")
+	}
+	log := d.log.WithFields(logrus.Fields{
+		"req_name":              name,
+		"req_source_volume_ids": req.GetSourceVolumeIds(),
+		"method":                "controller_create_volume_group_snapshot",
+	})
+	var snapshots []string
+	for _, v := range req.GetSourceVolumeIds() {
+		snapshots = append(snapshots, groupMemberID(name, v))
+	}
+	if g, ok := d.groupSnapshots[name]; ok {
+		if !sameIDs(g.snapshots, snapshots) {
+			return nil, status.Errorf(codes.AlreadyExists, "Group snapshot %s already exists with different volumes", name)
+		}
+		log.Info("Group snapshot already created")
+		return &csiext.CreateVolumeGroupSnapshotResponse{GroupSnapshot: d.groupSnapshotResponse(name, g)}, nil
+	}
+
+	group := &qsd.GroupSnapshot{ID: name}
+	var node string
+	for k, v := range req.GetSourceVolumeIds() {
+		source, ok := d.storage[v]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "Source volume %s not found in the storage", v)
+		}
+		if node != "" && source.node != node {
+			return nil, status.Errorf(codes.InvalidArgument, "The volumes of the group snapshot %s need to be on the same node", name)
+		}
+		node = source.node
+		group.Snapshots = append(group.Snapshots, &qsd.Snapshot{
+			ID:             snapshots[k],
+			SourceVolumeID: v,
+		})
+	}
+	client, err := d.qsdClient(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	log.Info("create group snapshot with the QSD")
+	resp, err := client.CreateGroupSnapshot(callCtx, group)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error in creating the group snapshot %v", err)
+	}
+	if len(resp.Snapshots) != len(group.Snapshots) {
+		return nil, status.Errorf(codes.Internal, "The QSD returned %d snapshots for the %d volumes of the group snapshot %s", len(resp.Snapshots), len(group.Snapshots), name)
+	}
+	// The snapshots of the group share the creation time
+	created := time.Unix(0, resp.Snapshots[0].CreationTime)
+	for k, s := range group.Snapshots {
+		d.snapshots[s.ID] = Snapshot{
+			baseID:  s.ID,
+			node:    node,
+			source:  s.SourceVolumeID,
+			size:    resp.Snapshots[k].RestoreSize,
+			created: time.Unix(0, resp.Snapshots[k].CreationTime),
+		}
+	}
+	g := GroupSnapshot{
+		node:      node,
+		snapshots: snapshots,
+		created:   created,
+	}
+	d.groupSnapshots[name] = g
+	log.Info("group snapshot created")
+	return &csiext.CreateVolumeGroupSnapshotResponse{GroupSnapshot: d.groupSnapshotResponse(name, g)}, nil
+}
+
+// DeleteVolumeGroupSnapshot deletes the snapshots of the group
+func (d *Driver) DeleteVolumeGroupSnapshot(ctx context.Context, req *csiext.DeleteVolumeGroupSnapshotRequest) (*csiext.DeleteVolumeGroupSnapshotResponse, error) {
+	id := req.GetGroupSnapshotId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "DeleteVolumeGroupSnapshot Group Snapshot ID must be provided")
+	}
+	g, ok := d.groupSnapshots[id]
+	if !ok {
+		// do not return an error because the group snapshot might be already deleted
+		d.log.Errorf("Failed to delete group snapshot %s: because not found", id)
+		return &csiext.DeleteVolumeGroupSnapshotResponse{}, nil
+	}
+	if len(req.GetSnapshotIds()) > 0 && !sameIDs(g.snapshots, req.GetSnapshotIds()) {
+		return nil, status.Errorf(codes.InvalidArgument, "The snapshots don't match the group snapshot %s", id)
+	}
+	for _, s := range g.snapshots {
+		if _, err := d.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{SnapshotId: s}); err != nil {
+			return nil, err
+		}
+	}
+	delete(d.groupSnapshots, id)
+	return &csiext.DeleteVolumeGroupSnapshotResponse{}, nil
+}
+
+func (d *Driver) GetVolumeGroupSnapshot(ctx context.Context, req *csiext.GetVolumeGroupSnapshotRequest) (*csiext.GetVolumeGroupSnapshotResponse, error) {
+	id := req.GetGroupSnapshotId()
+	g, ok := d.groupSnapshots[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Group snapshot %s not found", id)
+	}
+	if len(req.GetSnapshotIds()) > 0 && !sameIDs(g.snapshots, req.GetSnapshotIds()) {
+		return nil, status.Errorf(codes.InvalidArgument, "The snapshots don't match the group snapshot %s", id)
+	}
+	return &csiext.GetVolumeGroupSnapshotResponse{GroupSnapshot: d.groupSnapshotResponse(id, g)}, nil
+}
+
+func (d *Driver) groupSnapshotResponse(id string, g GroupSnapshot) *csiext.VolumeGroupSnapshot {
+	tstamp, err := ptypes.TimestampProto(g.created)
+	if err != nil {
+		d.log.Errorf("Failed converting the creation time of %s: %v", id, err)
+	}
+	resp := &csiext.VolumeGroupSnapshot{
+		GroupSnapshotId: id,
+		CreationTime:    tstamp,
+		ReadyToUse:      true,
+	}
+	for _, s := range g.snapshots {
+		created, err := ptypes.TimestampProto(d.snapshots[s].created)
+		if err != nil {
+			d.log.Errorf("Failed converting the creation time of %s: %v", s, err)
+		}
+		resp.Snapshots = append(resp.Snapshots, &csi.Snapshot{
+			SnapshotId:     s,
+			SourceVolumeId: d.snapshots[s].source,
+			SizeBytes:      d.snapshots[s].size,
+			CreationTime:   created,
+			ReadyToUse:     true,
+		})
+	}
+	return resp
+}
+
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	return strings.Join(sa, ",") == strings.Join(sb, ",")
+}
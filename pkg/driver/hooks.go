@@ -0,0 +1,267 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// VolumeSnapshotClass parameters of the hooks run around the snapshots. The exec
+// hooks run the command with sh -c in the pods using the volume, the http hooks
+// POST the phase, the volume and the snapshot to the URL.
+const (
+	paramPreHookExec       = "preHookExec"
+	paramPostHookExec      = "postHookExec"
+	paramPreHookURL        = "preHookURL"
+	paramPostHookURL       = "postHookURL"
+	paramHookContainer     = "hookContainer"
+	paramHookTimeout       = "hookTimeout"
+	paramHookFailurePolicy = "hookFailurePolicy"
+
+	// Parameters passed by the csi-snapshotter with --extra-create-metadata
+	paramSnapshotName      = "csi.storage.k8s.io/volumesnapshot/name"
+	paramSnapshotNamespace = "csi.storage.k8s.io/volumesnapshot/namespace"
+
+	defaultHookTimeout = 30 * time.Second
+)
+
+// Failure policies of the hooks, with Fail a failed pre hook aborts the snapshot
+const (
+	hookFail     = "Fail"
+	hookContinue = "Continue"
+)
+
+type hooks struct {
+	preExec   string
+	postExec  string
+	preURL    string
+	postURL   string
+	container string
+	timeout   time.Duration
+	policy    string
+}
+
+// parseHooks returns the hooks configured in the parameters, nil without hooks
+func parseHooks(params map[string]string) (*hooks, error) {
+	h := &hooks{
+		preExec:   params[paramPreHookExec],
+		postExec:  params[paramPostHookExec],
+		preURL:    params[paramPreHookURL],
+		postURL:   params[paramPostHookURL],
+		container: params[paramHookContainer],
+		timeout:   defaultHookTimeout,
+		policy:    hookFail,
+	}
+	if h.preExec == "" && h.postExec == "" && h.preURL == "" && h.postURL == "" {
+		return nil, nil
+	}
+	if v, ok := params[paramHookTimeout]; ok {
+		t, err := time.ParseDuration(v)
+		if err != nil || t <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be a positive duration", paramHookTimeout, v)
+		}
+		h.timeout = t
+	}
+	if v, ok := params[paramHookFailurePolicy]; ok {
+		if v != hookFail && v != hookContinue {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be %s or %s", paramHookFailurePolicy, v, hookFail, hookContinue)
+		}
+		h.policy = v
+	}
+	return h, nil
+}
+
+// hookRequest is the body of the http hooks
+type hookRequest struct {
+	Phase             string `json:"phase"`
+	VolumeID          string `json:"volumeID"`
+	SnapshotName      string `json:"snapshotName,omitempty"`
+	SnapshotNamespace string `json:"snapshotNamespace,omitempty"`
+}
+
+// runHooks runs the hooks of the phase and records the results as events on the
+// VolumeSnapshot. The error is returned only if the snapshot needs to be aborted.
+func (d *Driver) runHooks(ctx context.Context, h *hooks, phase, volumeID string, params map[string]string) error {
+	command, url := h.preExec, h.preURL
+	if phase == "post" {
+		command, url = h.postExec, h.postURL
+	}
+	log := d.log.WithFields(logrus.Fields{
+		"volume_id": volumeID,
+		"phase":     phase,
+		"method":    "snapshot_hooks",
+	})
+	var errs []string
+	if command != "" {
+		if err := d.execHook(ctx, h, command, volumeID); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if url != "" {
+		if err := httpHook(ctx, h.timeout, url, &hookRequest{
+			Phase:             phase,
+			VolumeID:          volumeID,
+			SnapshotName:      params[paramSnapshotName],
+			SnapshotNamespace: params[paramSnapshotNamespace],
+		}); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		log.Info("snapshot hooks succeeded")
+		d.snapshotEvent(params, corev1.EventTypeNormal, "HookSucceeded", fmt.Sprintf("The %s-snapshot hooks succeeded", phase))
+		return nil
+	}
+	message := fmt.Sprintf("The %s-snapshot hooks failed: %s", phase, strings.Join(errs, "; "))
+	log.Error(message)
+	d.snapshotEvent(params, corev1.EventTypeWarning, "HookFailed", message)
+	// The snapshot is already taken when the post hooks run
+	if phase == "pre" && h.policy == hookFail {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+	return nil
+}
+
+// postHooks runs the post hooks with their own deadline, the context of the request
+// might be already expired by the pre hooks or the snapshot
+func (d *Driver) postHooks(h *hooks, volumeID string, params map[string]string) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	d.runHooks(ctx, h, "post", volumeID, params)
+}
+
+// execHook runs the command in the running pods using the volume
+func (d *Driver) execHook(ctx context.Context, h *hooks, command, volumeID string) error {
+	if d.kube == nil || d.kubeConfig == nil {
+		return fmt.Errorf("exec hooks need the Kubernetes API")
+	}
+	pods, err := d.volumePods(ctx, volumeID)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, pod := range pods {
+		container := h.container
+		if container == "" {
+			container = pod.Spec.Containers[0].Name
+		}
+		hctx, cancel := context.WithTimeout(ctx, h.timeout)
+		out, err := d.execInPod(hctx, pod, container, []string{"sh", "-c", command})
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pod %s/%s: %v %s", pod.Namespace, pod.Name, err, out))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// volumePods returns the running pods using the PVC bound to the volume
+func (d *Driver) volumePods(ctx context.Context, volumeID string) ([]*corev1.Pod, error) {
+	// The volumes are named after their PV
+	pv, err := d.kube.CoreV1().PersistentVolumes().Get(ctx, volumeID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	claim := pv.Spec.ClaimRef
+	if claim == nil {
+		return nil, nil
+	}
+	list, err := d.kube.CoreV1().Pods(claim.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("status.phase=%s", corev1.PodRunning),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range list.Items {
+		for _, v := range list.Items[i].Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claim.Name {
+				pods = append(pods, &list.Items[i])
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
+func (d *Driver) execInPod(ctx context.Context, pod *corev1.Pod, container string, command []string) (string, error) {
+	req := d.kube.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(d.kubeConfig, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- exec.Stream(remotecommand.StreamOptions{Stdout: &out, Stderr: &out})
+	}()
+	// The executor doesn't support the contexts, the command keeps running in the pod
+	// after the timeout
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("hook timed out")
+	case err := <-done:
+		return out.String(), err
+	}
+}
+
+func httpHook(ctx context.Context, timeout time.Duration, url string, r *hookRequest) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// snapshotEvent records the event on the VolumeSnapshot if known
+func (d *Driver) snapshotEvent(params map[string]string, eventType, reason, message string) {
+	if d.recorder == nil || params[paramSnapshotName] == "" {
+		return
+	}
+	d.recorder.Event(&corev1.ObjectReference{
+		APIVersion: "snapshot.storage.k8s.io/v1",
+		Kind:       "VolumeSnapshot",
+		Name:       params[paramSnapshotName],
+		Namespace:  params[paramSnapshotNamespace],
+	}, eventType, reason, message)
+}
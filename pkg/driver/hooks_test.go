@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseHooks(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   *hooks
+		code   codes.Code
+	}{
+		{name: "no hooks", params: map[string]string{paramHookTimeout: "1m"}, code: codes.OK},
+		{
+			name:   "defaults",
+			params: map[string]string{paramPreHookExec: "fsfreeze -f /data"},
+			want:   &hooks{preExec: "fsfreeze -f /data", timeout: defaultHookTimeout, policy: hookFail},
+			code:   codes.OK,
+		},
+		{
+			name: "all",
+			params: map[string]string{
+				paramPreHookExec:       "fsfreeze -f /data",
+				paramPostHookExec:      "fsfreeze -u /data",
+				paramPreHookURL:        "http://app/pre",
+				paramPostHookURL:       "http://app/post",
+				paramHookContainer:     "db",
+				paramHookTimeout:       "10s",
+				paramHookFailurePolicy: hookContinue,
+			},
+			want: &hooks{
+				preExec:   "fsfreeze -f /data",
+				postExec:  "fsfreeze -u /data",
+				preURL:    "http://app/pre",
+				postURL:   "http://app/post",
+				container: "db",
+				timeout:   10 * time.Second,
+				policy:    hookContinue,
+			},
+			code: codes.OK,
+		},
+		{name: "invalid timeout", params: map[string]string{paramPostHookURL: "http://app/post", paramHookTimeout: "soon"}, code: codes.InvalidArgument},
+		{name: "negative timeout", params: map[string]string{paramPostHookURL: "http://app/post", paramHookTimeout: "-1s"}, code: codes.InvalidArgument},
+		{name: "invalid failure policy", params: map[string]string{paramPreHookURL: "http://app/pre", paramHookFailurePolicy: "Ignore"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHooks(tt.params)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("parseHooks(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHooks(%v) = %+v, want %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
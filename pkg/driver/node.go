@@ -2,16 +2,40 @@ package driver
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"syscall"
 
+	"github.com/alicefr/csi-qsd/pkg/qsd"
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// paramPublishSnapshot is set in the volume attributes of a static PV whose volume
+// handle is a snapshot ID, the snapshot is published read-only without cloning it
+const paramPublishSnapshot = "snapshot"
+
+// exportID identifies the export of a publish from its target path
+func exportID(targetPath string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(targetPath)))[:16]
+}
+
+// isReadOnly returns if the publish only needs to read the volume
+func isReadOnly(req *csi.NodePublishVolumeRequest) bool {
+	if req.GetReadonly() {
+		return true
+	}
+	switch req.GetVolumeCapability().GetAccessMode().GetMode() {
+	case csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+		csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:
+		return true
+	}
+	return false
+}
+
 func (s *Driver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
 	volumeID := req.GetVolumeId()
 	log := s.log.WithFields(logrus.Fields{
@@ -39,14 +63,42 @@ func (s *Driver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolu
 		return nil, status.Errorf(codes.Internal, "mkdir failed: target=%s, error=%v", req.GetTargetPath(), err)
 	}
 
-	// Mount vhost-user socket dir into the target directory
-	socketDir := fmt.Sprintf("%s/%s", SocketDir, volumeID)
-	//socket := fmt.Sprintf("%s/%s/%s", socketDir, vhostSock)
-	//if _, err := os.Stat(socket); err != nil {
-	//	return nil, status.Errorf(codes.Internal, "failed in stating the socket for volume %s: %v", volumeID, err)
-	//}
+	snapshot := req.GetVolumeContext()[paramPublishSnapshot] == "true"
+	if snapshot && !isReadOnly(req) {
+		return nil, status.Errorf(codes.InvalidArgument, "Snapshot %s can only be published read-only", volumeID)
+	}
+	// Every publish gets its own export of the volume
+	image := &qsd.Image{
+		ID:             volumeID,
+		ExportID:       exportID(req.GetTargetPath()),
+		ReadOnly:       isReadOnly(req),
+		SharedWritable: req.GetVolumeContext()[paramSharedWritable] == "true",
+	}
+	client, err := s.qsdClient(ctx, s.nodeId)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	var r *qsd.Response
+	if snapshot {
+		r, err = client.ExposeSnapshot(callCtx, image)
+	} else {
+		r, err = client.ExposeVhostUser(callCtx, image)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !r.Success {
+		return nil, status.Error(codes.Internal, r.Message)
+	}
 
+	// Mount vhost-user socket dir into the target directory
+	socketDir := qsd.PublishSocketDir(volumeID, image.ExportID)
 	if err := syscall.Mount(socketDir, req.GetTargetPath(), "none", syscall.MS_BIND, ""); err != nil {
+		if _, err := client.DeleteExporter(context.Background(), image); err != nil {
+			log.Errorf("Failed removing the export %s: %v", image.ExportID, err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed in mounting the socket dir for volume %s: %v", volumeID, err)
 	}
 
@@ -69,6 +121,18 @@ func (s *Driver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublish
 	if err := os.RemoveAll(req.GetTargetPath()); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed in removing the target dir for volume %s: %v", volumeID, err)
 	}
+	client, err := s.qsdClient(ctx, s.nodeId)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	if _, err := client.DeleteExporter(callCtx, &qsd.Image{
+		ID:       volumeID,
+		ExportID: exportID(req.GetTargetPath()),
+	}); err != nil {
+		return nil, err
+	}
 	log.Info("volume unpublished")
 	return &csi.NodeUnpublishVolumeResponse{}, nil
 }
@@ -95,5 +159,8 @@ func (s *Driver) NodeGetCapabilities(context.Context, *csi.NodeGetCapabilitiesRe
 func (s *Driver) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
 	return &csi.NodeGetInfoResponse{
 		NodeId: s.nodeId,
+		AccessibleTopology: &csi.Topology{
+			Segments: map[string]string{TopologyKey: s.nodeId},
+		},
 	}, nil
 }
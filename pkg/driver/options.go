@@ -0,0 +1,107 @@
+package driver
+
+import (
+	"strconv"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StorageClass parameters for the vhost-user-blk exports
+const (
+	paramNumQueues        = "numQueues"
+	paramLogicalBlockSize = "logicalBlockSize"
+	paramWritethrough     = "writethrough"
+	paramDiscard          = "discard"
+)
+
+// StorageClass parameters for the access to the image files
+const (
+	paramCacheDirect  = "cacheDirect"
+	paramCacheNoFlush = "cacheNoFlush"
+	paramAio          = "aio"
+	paramDetectZeroes = "detectZeroes"
+)
+
+// paramSnapshotMode selects how the snapshots are taken, in the StorageClass for all
+// the snapshots of the volume or in the VolumeSnapshotClass. The external snapshots
+// add an overlay to the backing chain, the internal ones are stored in the qcow2
+// image of the volume. The group snapshots are always external.
+const (
+	paramSnapshotMode    = "snapshotMode"
+	snapshotModeExternal = "external"
+	snapshotModeInternal = "internal"
+)
+
+// snapshotMode parses the snapshot mode from the parameters, def is used if unset
+func snapshotMode(params map[string]string, def string) (string, error) {
+	v, ok := params[paramSnapshotMode]
+	if !ok {
+		return def, nil
+	}
+	if v != snapshotModeExternal && v != snapshotModeInternal {
+		return "", status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be %s or %s", paramSnapshotMode, v, snapshotModeExternal, snapshotModeInternal)
+	}
+	return v, nil
+}
+
+// exportOptions parses the export options from the parameters of the StorageClass
+func exportOptions(params map[string]string) (*qsd.ExportOptions, error) {
+	o := &qsd.ExportOptions{}
+	if v, ok := params[paramNumQueues]; ok {
+		n, err := strconv.ParseUint(v, 10, 16)
+		if err != nil || n == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be a positive number", paramNumQueues, v)
+		}
+		o.NumQueues = uint32(n)
+	}
+	if v, ok := params[paramLogicalBlockSize]; ok {
+		n, err := strconv.ParseUint(v, 10, 32)
+		// Same limits of qemu
+		if err != nil || n < 512 || n > 32768 || n&(n-1) != 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be a power of 2 between 512 and 32768", paramLogicalBlockSize, v)
+		}
+		o.LogicalBlockSize = uint32(n)
+	}
+	var err error
+	if o.Writethrough, err = boolParam(params, paramWritethrough); err != nil {
+		return nil, err
+	}
+	if o.Discard, err = boolParam(params, paramDiscard); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// ioOptions parses the I/O options from the parameters of the StorageClass. The
+// support of io_uring is verified by the qsd server on the node.
+func ioOptions(params map[string]string, export *qsd.ExportOptions) (*qsd.IOOptions, error) {
+	o := &qsd.IOOptions{
+		Aio:          params[paramAio],
+		DetectZeroes: params[paramDetectZeroes],
+	}
+	var err error
+	if o.CacheDirect, err = boolParam(params, paramCacheDirect); err != nil {
+		return nil, err
+	}
+	if o.CacheNoFlush, err = boolParam(params, paramCacheNoFlush); err != nil {
+		return nil, err
+	}
+	if err := qsd.ValidateOptions(export, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func boolParam(params map[string]string, name string) (bool, error) {
+	v, ok := params[name]
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be true or false", name, v)
+	}
+	return b, nil
+}
@@ -0,0 +1,115 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExportOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   *qsd.ExportOptions
+		code   codes.Code
+	}{
+		{name: "defaults", want: &qsd.ExportOptions{}, code: codes.OK},
+		{
+			name: "all",
+			params: map[string]string{
+				paramNumQueues:        "4",
+				paramLogicalBlockSize: "4096",
+				paramWritethrough:     "true",
+				paramDiscard:          "true",
+			},
+			want: &qsd.ExportOptions{NumQueues: 4, LogicalBlockSize: 4096, Writethrough: true, Discard: true},
+			code: codes.OK,
+		},
+		{name: "zero queues", params: map[string]string{paramNumQueues: "0"}, code: codes.InvalidArgument},
+		{name: "too many queues", params: map[string]string{paramNumQueues: "65536"}, code: codes.InvalidArgument},
+		{name: "block size too small", params: map[string]string{paramLogicalBlockSize: "256"}, code: codes.InvalidArgument},
+		{name: "block size too large", params: map[string]string{paramLogicalBlockSize: "65536"}, code: codes.InvalidArgument},
+		{name: "block size not a power of 2", params: map[string]string{paramLogicalBlockSize: "1000"}, code: codes.InvalidArgument},
+		{name: "invalid discard", params: map[string]string{paramDiscard: "yes"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exportOptions(tt.params)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("exportOptions(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("exportOptions(%v) = %v, want %v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIOOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		export *qsd.ExportOptions
+		want   *qsd.IOOptions
+		code   codes.Code
+	}{
+		{name: "defaults", want: &qsd.IOOptions{}, code: codes.OK},
+		{
+			name:   "native",
+			params: map[string]string{paramAio: "native", paramCacheDirect: "true", paramCacheNoFlush: "false"},
+			want:   &qsd.IOOptions{Aio: "native", CacheDirect: true},
+			code:   codes.OK,
+		},
+		{
+			name:   "unmap with discard",
+			params: map[string]string{paramDetectZeroes: "unmap"},
+			export: &qsd.ExportOptions{Discard: true},
+			want:   &qsd.IOOptions{DetectZeroes: "unmap"},
+			code:   codes.OK,
+		},
+		{name: "native without direct cache", params: map[string]string{paramAio: "native"}, code: codes.InvalidArgument},
+		{name: "unmap without discard", params: map[string]string{paramDetectZeroes: "unmap"}, code: codes.InvalidArgument},
+		{name: "invalid aio", params: map[string]string{paramAio: "posix"}, code: codes.InvalidArgument},
+		{name: "invalid cache direct", params: map[string]string{paramCacheDirect: "direct"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ioOptions(tt.params, tt.export)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("ioOptions(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("ioOptions(%v) = %v, want %v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		def    string
+		want   string
+		code   codes.Code
+	}{
+		{name: "default", def: snapshotModeExternal, want: snapshotModeExternal, code: codes.OK},
+		{name: "internal", params: map[string]string{paramSnapshotMode: snapshotModeInternal}, def: snapshotModeExternal, want: snapshotModeInternal, code: codes.OK},
+		{name: "external", params: map[string]string{paramSnapshotMode: snapshotModeExternal}, def: snapshotModeInternal, want: snapshotModeExternal, code: codes.OK},
+		{name: "invalid", params: map[string]string{paramSnapshotMode: "inline"}, def: snapshotModeExternal, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := snapshotMode(tt.params, tt.def)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("snapshotMode(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if got != tt.want {
+				t.Errorf("snapshotMode(%v) = %q, want %q", tt.params, got, tt.want)
+			}
+		})
+	}
+}
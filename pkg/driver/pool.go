@@ -0,0 +1,224 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// qsdCallTimeout is applied to the calls to the qsd servers when the CSI request has no deadline
+	qsdCallTimeout = 30 * time.Second
+	maxRetries     = 5
+	minRetryDelay  = 200 * time.Millisecond
+	maxRetryDelay  = 5 * time.Second
+)
+
+// nodeResolver returns the address of the qsd server running on a node
+type nodeResolver interface {
+	resolve(ctx context.Context, node string) (string, error)
+}
+
+// hostnameResolver expects the node names to resolve as hostnames
+type hostnameResolver struct{}
+
+func (hostnameResolver) resolve(_ context.Context, node string) (string, error) {
+	return node, nil
+}
+
+// podResolver looks up the IP of the qsd DaemonSet pod scheduled on the node
+type podResolver struct {
+	client    kubernetes.Interface
+	namespace string
+	selector  string
+}
+
+func (r *podResolver) resolve(ctx context.Context, node string) (string, error) {
+	pods, err := r.client.CoreV1().Pods(r.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: r.selector,
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed listing the qsd pods on node %s: %v", node, err)
+	}
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning && p.Status.PodIP != "" && p.DeletionTimestamp == nil {
+			return p.Status.PodIP, nil
+		}
+	}
+	return "", fmt.Errorf("no running qsd pod found on node %s", node)
+}
+
+type pooledConn struct {
+	addr string
+	conn *grpc.ClientConn
+}
+
+// clientPool keeps a connection per node to the qsd servers
+type clientPool struct {
+	mu       sync.Mutex
+	port     string
+	resolver nodeResolver
+	conns    map[string]*pooledConn
+	log      *logrus.Entry
+}
+
+func newClientPool(port string, resolver nodeResolver, log *logrus.Entry) *clientPool {
+	return &clientPool{
+		port:     port,
+		resolver: resolver,
+		conns:    make(map[string]*pooledConn),
+		log:      log,
+	}
+}
+
+// get returns the client for the node. The address of the node is resolved again
+// when the connection is failing, for example because the qsd pod was recreated.
+// The lock isn't held during the resolution to not block the calls to the other nodes.
+func (p *clientPool) get(ctx context.Context, node string) (qsd.QsdServiceClient, error) {
+	p.mu.Lock()
+	c, ok := p.conns[node]
+	p.mu.Unlock()
+	if ok {
+		switch c.conn.GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+		default:
+			return qsd.NewQsdServiceClient(c.conn), nil
+		}
+	}
+	addr, err := p.resolver.resolve(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// The connection might have been replaced by a concurrent call in the meantime
+	c, ok = p.conns[node]
+	if ok && c.addr == addr && c.conn.GetState() != connectivity.Shutdown {
+		// Same address, let the connection reconnect
+		return qsd.NewQsdServiceClient(c.conn), nil
+	}
+	conn, err := grpc.Dial(net.JoinHostPort(addr, p.port),
+		grpc.WithInsecure(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithUnaryInterceptor(retryUnavailable),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		p.log.Infof("qsd server on node %s moved from %s to %s", node, c.addr, addr)
+		c.conn.Close()
+	}
+	p.conns[node] = &pooledConn{addr: addr, conn: conn}
+	return qsd.NewQsdServiceClient(conn), nil
+}
+
+// address returns the address of the qsd server on the node
+func (p *clientPool) address(ctx context.Context, node string) (string, error) {
+	p.mu.Lock()
+	c, ok := p.conns[node]
+	p.mu.Unlock()
+	if ok && c.conn.GetState() != connectivity.TransientFailure && c.conn.GetState() != connectivity.Shutdown {
+		return c.addr, nil
+	}
+	return p.resolver.resolve(ctx, node)
+}
+
+func (p *clientPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for node, c := range p.conns {
+		c.conn.Close()
+		delete(p.conns, node)
+	}
+}
+
+// retryableMethods are the read-only calls and the calls recognized by the qsd
+// server when repeated. A call failing with Unavailable might have been executed
+// before the connection broke, the others are left to the retries of the CO.
+var retryableMethods = map[string]bool{
+	"ListVolumes":         true,
+	"Health":              true,
+	"CreateSnapshot":      true,
+	"CreateGroupSnapshot": true,
+	"ExposeSnapshot":      true,
+}
+
+// retryUnavailable retries with an exponential backoff the retryable calls failing
+// because the qsd server is not reachable or temporarily unavailable
+func retryUnavailable(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !retryableMethods[path.Base(method)] {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	delay := minRetryDelay
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+	return err
+}
+
+// callContext derives the context for the calls to the qsd servers from the CSI request
+func callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, qsdCallTimeout)
+}
+
+// qsdClient returns the client for the qsd server on the node
+func (d *Driver) qsdClient(ctx context.Context, node string) (qsd.QsdServiceClient, error) {
+	d.poolOnce.Do(func() {
+		if d.pool == nil {
+			d.pool = newClientPool(d.port, hostnameResolver{}, d.log)
+		}
+	})
+	client, err := d.pool.get(ctx, node)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Failed to connect to the QSD server for node %s:%v", node, err)
+	}
+	return client, nil
+}
+
+// qsdAddress returns the address where the qsd server on the node is reachable
+func (d *Driver) qsdAddress(ctx context.Context, node string) (string, error) {
+	if _, err := d.qsdClient(ctx, node); err != nil {
+		return "", err
+	}
+	addr, err := d.pool.address(ctx, node)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "Failed to resolve the QSD server for node %s:%v", node, err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,203 @@
+package driver
+
+import (
+	"context"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/metadata"
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// paramReplication replicates the volume on a second node. With sync the writes
+// complete only once stored on both nodes, with async the writes are copied on the
+// replica every paramRPO. paramReplicaNode selects the node of the replica.
+const (
+	paramReplication = "replication"
+	paramRPO         = "rpo"
+	paramReplicaNode = "replicaNode"
+	replicationSync  = "sync"
+	replicationAsync = "async"
+	defaultRPO       = 15 * time.Minute
+)
+
+// replicationMode parses the replication mode and the RPO from the StorageClass
+// parameters, the mode is empty without replication
+func replicationMode(params map[string]string) (string, time.Duration, error) {
+	v, ok := params[paramReplication]
+	if !ok {
+		return "", 0, nil
+	}
+	if v != replicationSync && v != replicationAsync {
+		return "", 0, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be %s or %s", paramReplication, v, replicationSync, replicationAsync)
+	}
+	rpo := defaultRPO
+	if r, ok := params[paramRPO]; ok {
+		var err error
+		if rpo, err = time.ParseDuration(r); err != nil || rpo < time.Second {
+			return "", 0, status.Errorf(codes.InvalidArgument, "Invalid %s %q: must be a duration of at least 1s", paramRPO, r)
+		}
+	}
+	return v, rpo, nil
+}
+
+// selectReplicaNode returns the node for the replica, the node in the parameters or
+// the first node in the topology requirement other than the primary
+func selectReplicaNode(params map[string]string, req *csi.TopologyRequirement, primary string) (string, error) {
+	if node, ok := params[paramReplicaNode]; ok {
+		if node == primary {
+			return "", status.Errorf(codes.InvalidArgument, "The replica node %s is the node of the volume", node)
+		}
+		return node, nil
+	}
+	for _, t := range append(req.GetPreferred(), req.GetRequisite()...) {
+		if node, ok := t.GetSegments()[TopologyKey]; ok && node != primary {
+			return node, nil
+		}
+	}
+	return "", status.Errorf(codes.ResourceExhausted, "No node available for the replica of a volume on %s", primary)
+}
+
+// replicate creates the replica of the volume and starts the replication from the
+// primary. Both calls are idempotent and repeated with the retries of CreateVolume.
+func (d *Driver) replicate(ctx context.Context, v Volume, image *qsd.Image) error {
+	replica, err := d.qsdClient(ctx, v.replica)
+	if err != nil {
+		return err
+	}
+	primary, err := d.qsdClient(ctx, v.node)
+	if err != nil {
+		return err
+	}
+	host, err := d.qsdAddress(ctx, v.replica)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	exp, err := replica.CreateReplica(callCtx, &qsd.ReplicaParams{
+		VolumeID:     image.ID,
+		Size:         image.Size,
+		PVCName:      image.PVCName,
+		PVCNamespace: image.PVCNamespace,
+		Export:       image.Export,
+		IO:           image.IO,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error for creating the replica on %s: %v", v.replica, err)
+	}
+	if !exp.Success {
+		return status.Error(codes.Internal, exp.Message)
+	}
+	r, err := primary.StartReplication(callCtx, &qsd.ReplicationParams{
+		VolumeID:   image.ID,
+		Host:       host,
+		Port:       exp.Port,
+		ExportName: exp.ExportName,
+		TLS:        exp.TLS,
+		Async:      v.replicationMode == replicationAsync,
+		RPO:        int64(v.rpo.Seconds()),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error for starting the replication to %s: %v", v.replica, err)
+	}
+	if !r.Success {
+		return status.Error(codes.Internal, r.Message)
+	}
+	return nil
+}
+
+// deleteReplica removes the replica of the volume
+func (d *Driver) deleteReplica(ctx context.Context, v Volume) error {
+	client, err := d.qsdClient(ctx, v.replica)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	if _, err := client.DeleteVolume(callCtx, &qsd.Image{ID: v.id}); err != nil {
+		return status.Errorf(codes.Internal, "Error for deleting the replica on %s: %v", v.replica, err)
+	}
+	return nil
+}
+
+// replicaContext records the replica node in the attributes of the PV, the
+// metadata server reads it from there
+func replicaContext(v Volume, ctx map[string]string) map[string]string {
+	if v.replica == "" {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = make(map[string]string)
+	}
+	ctx[metadata.AttrReplicaNode] = v.replica
+	return ctx
+}
+
+// PromoteReplica promotes the replica of the volume after the loss of the primary
+// node, the volume is then stored on the node of the replica and isn't replicated
+// anymore. The node affinity of the PV is immutable and still points to the lost
+// primary, the PV needs to be re-created with the node returned as described in
+// examples/storageclass_replicated.yaml.
+func (d *Driver) PromoteReplica(ctx context.Context, req *csiext.PromoteReplicaRequest) (*csiext.PromoteReplicaResponse, error) {
+	id := req.GetVolumeId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "PromoteReplica Volume ID must be provided")
+	}
+	log := d.log.WithFields(logrus.Fields{
+		"volume_id": id,
+		"method":    "controller_promote_replica",
+	})
+	v, ok := d.storage[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Volume %s not found in the storage", id)
+	}
+	if v.replica == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "Volume %s on %s has no replica", id, v.node)
+	}
+	client, err := d.qsdClient(ctx, v.replica)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	log.Infof("promote the replica on %s", v.replica)
+	r, err := client.PromoteReplica(callCtx, &qsd.Image{ID: id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error for promoting the replica on %s: %v", v.replica, err)
+	}
+	if !r.Success {
+		return nil, status.Error(codes.Internal, r.Message)
+	}
+	// The promotion is idempotent, the state is updated only once the PV has moved
+	// to allow the retries
+	if err := d.moveVolume(ctx, id, v.node, v.replica); err != nil {
+		return nil, err
+	}
+	v.node, v.replica, v.replicationMode, v.rpo = v.replica, "", "", 0
+	d.storage[id] = v
+	log.Infof("replica promoted, the volume is on %s", v.node)
+	return &csiext.PromoteReplicaResponse{NodeId: v.node}, nil
+}
+
+// moveVolume updates the node label of the PV read by the qsd servers to restore
+// their volumes
+func (d *Driver) moveVolume(ctx context.Context, id, from, to string) error {
+	if d.kube == nil {
+		d.log.Warnf("The PV of %s isn't moved to %s without the Kubernetes API", id, to)
+		return nil
+	}
+	m := &metadata.MetadataServer{Client: d.kube}
+	if _, err := m.MoveVolume(ctx, &metadata.MoveVolumeParams{
+		ID:       id,
+		FromNode: from,
+		ToNode:   to,
+	}); err != nil {
+		return status.Errorf(codes.Internal, "Error for moving the PV of %s to %s: %v", id, to, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"testing"
+	"time"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReplicationMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		mode   string
+		rpo    time.Duration
+		code   codes.Code
+	}{
+		{name: "no replication", params: map[string]string{paramRPO: "1m"}, code: codes.OK},
+		{name: "sync", params: map[string]string{paramReplication: replicationSync}, mode: replicationSync, rpo: defaultRPO, code: codes.OK},
+		{name: "async", params: map[string]string{paramReplication: replicationAsync, paramRPO: "5m"}, mode: replicationAsync, rpo: 5 * time.Minute, code: codes.OK},
+		{name: "invalid mode", params: map[string]string{paramReplication: "mirror"}, code: codes.InvalidArgument},
+		{name: "invalid rpo", params: map[string]string{paramReplication: replicationAsync, paramRPO: "often"}, code: codes.InvalidArgument},
+		{name: "rpo too short", params: map[string]string{paramReplication: replicationAsync, paramRPO: "500ms"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, rpo, err := replicationMode(tt.params)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("replicationMode(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if mode != tt.mode || rpo != tt.rpo {
+				t.Errorf("replicationMode(%v) = %q, %s, want %q, %s", tt.params, mode, rpo, tt.mode, tt.rpo)
+			}
+		})
+	}
+}
+
+func TestSelectReplicaNode(t *testing.T) {
+	topology := func(nodes ...string) []*csi.Topology {
+		var t []*csi.Topology
+		for _, n := range nodes {
+			t = append(t, &csi.Topology{Segments: map[string]string{TopologyKey: n}})
+		}
+		return t
+	}
+	tests := []struct {
+		name   string
+		params map[string]string
+		req    *csi.TopologyRequirement
+		want   string
+		code   codes.Code
+	}{
+		{name: "parameter", params: map[string]string{paramReplicaNode: "node3"}, req: &csi.TopologyRequirement{Preferred: topology("node2")}, want: "node3", code: codes.OK},
+		{name: "parameter on the primary", params: map[string]string{paramReplicaNode: "node1"}, code: codes.InvalidArgument},
+		{name: "preferred", req: &csi.TopologyRequirement{Preferred: topology("node1", "node2"), Requisite: topology("node3")}, want: "node2", code: codes.OK},
+		{name: "requisite", req: &csi.TopologyRequirement{Preferred: topology("node1"), Requisite: topology("node1", "node3")}, want: "node3", code: codes.OK},
+		{name: "only the primary", req: &csi.TopologyRequirement{Preferred: topology("node1")}, code: codes.ResourceExhausted},
+		{name: "no topology", code: codes.ResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectReplicaNode(tt.params, tt.req, "node1")
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("selectReplicaNode(%v) code %s, want %s: %v", tt.params, code, tt.code, err)
+			}
+			if got != tt.want {
+				t.Errorf("selectReplicaNode(%v) = %q, want %q", tt.params, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package driver
+
+import (
+	"context"
+	"io"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// snapshotMetadataService is the SNAPSHOT_METADATA_SERVICE plugin capability,
+// missing in the vendored spec
+const snapshotMetadataService = csi.PluginCapability_Service_Type(4)
+
+// GetMetadataAllocated streams the extents allocated in the snapshot
+func (d *Driver) GetMetadataAllocated(req *csiext.GetMetadataAllocatedRequest, stream csiext.SnapshotMetadata_GetMetadataAllocatedServer) error {
+	if req.GetSnapshotId() == "" {
+		return status.Error(codes.InvalidArgument, "GetMetadataAllocated Snapshot ID must be provided")
+	}
+	d.log.WithFields(logrus.Fields{
+		"req_snapshot_id":     req.GetSnapshotId(),
+		"req_starting_offset": req.GetStartingOffset(),
+		"method":              "get_metadata_allocated",
+	}).Info("get metadata allocated called")
+	return d.snapshotMetadata(stream.Context(), &qsd.SnapshotMetadataParams{
+		SnapshotID:     req.GetSnapshotId(),
+		StartingOffset: req.GetStartingOffset(),
+		MaxResults:     req.GetMaxResults(),
+	}, func(p *qsd.BlockMetadataPage) error {
+		return stream.Send(&csiext.GetMetadataAllocatedResponse{
+			BlockMetadataType:   csiext.BlockMetadataType_VARIABLE_LENGTH,
+			VolumeCapacityBytes: p.VolumeCapacity,
+			BlockMetadata:       blockMetadata(p.Extents),
+		})
+	})
+}
+
+// GetMetadataDelta streams the extents changed between the base and the target snapshots
+func (d *Driver) GetMetadataDelta(req *csiext.GetMetadataDeltaRequest, stream csiext.SnapshotMetadata_GetMetadataDeltaServer) error {
+	if req.GetBaseSnapshotId() == "" || req.GetTargetSnapshotId() == "" {
+		return status.Error(codes.InvalidArgument, "GetMetadataDelta Base and Target Snapshot IDs must be provided")
+	}
+	d.log.WithFields(logrus.Fields{
+		"req_base_snapshot_id":   req.GetBaseSnapshotId(),
+		"req_target_snapshot_id": req.GetTargetSnapshotId(),
+		"req_starting_offset":    req.GetStartingOffset(),
+		"method":                 "get_metadata_delta",
+	}).Info("get metadata delta called")
+	base, ok := d.snapshots[req.GetBaseSnapshotId()]
+	if !ok {
+		return status.Errorf(codes.NotFound, "Snapshot %s not found", req.GetBaseSnapshotId())
+	}
+	if target, ok := d.snapshots[req.GetTargetSnapshotId()]; ok && target.node != base.node {
+		return status.Errorf(codes.InvalidArgument, "Snapshots %s and %s are on different nodes", req.GetBaseSnapshotId(), req.GetTargetSnapshotId())
+	}
+	return d.snapshotMetadata(stream.Context(), &qsd.SnapshotMetadataParams{
+		SnapshotID:     req.GetTargetSnapshotId(),
+		BaseSnapshotID: req.GetBaseSnapshotId(),
+		StartingOffset: req.GetStartingOffset(),
+		MaxResults:     req.GetMaxResults(),
+	}, func(p *qsd.BlockMetadataPage) error {
+		return stream.Send(&csiext.GetMetadataDeltaResponse{
+			BlockMetadataType:   csiext.BlockMetadataType_VARIABLE_LENGTH,
+			VolumeCapacityBytes: p.VolumeCapacity,
+			BlockMetadata:       blockMetadata(p.Extents),
+		})
+	})
+}
+
+// snapshotMetadata streams the pages of extents of the snapshot from the qsd server
+// on its node
+func (d *Driver) snapshotMetadata(ctx context.Context, p *qsd.SnapshotMetadataParams, send func(*qsd.BlockMetadataPage) error) error {
+	s, ok := d.snapshots[p.SnapshotID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "Snapshot %s not found", p.SnapshotID)
+	}
+	client, err := d.qsdClient(ctx, s.node)
+	if err != nil {
+		return err
+	}
+	stream, err := client.SnapshotMetadata(ctx, p)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error in getting the metadata of the snapshot %s: %v", p.SnapshotID, err)
+	}
+	for {
+		page, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		// Keep the code of the qsd server for the invalid requests
+		if err != nil {
+			return err
+		}
+		if err := send(page); err != nil {
+			return err
+		}
+	}
+}
+
+func blockMetadata(extents []*qsd.BlockExtent) []*csiext.BlockMetadata {
+	var m []*csiext.BlockMetadata
+	for _, e := range extents {
+		m = append(m, &csiext.BlockMetadata{
+			ByteOffset: e.Offset,
+			SizeBytes:  e.Length,
+		})
+	}
+	return m
+}
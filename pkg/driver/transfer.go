@@ -0,0 +1,258 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/csiext"
+	"github.com/alicefr/csi-qsd/pkg/qsd"
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// transferTimeout bounds the copy of a volume between two nodes or from an image
+	transferTimeout = 2 * time.Hour
+
+	// Parameters of the StorageClass to create the volumes from an image
+	paramImageSource = "imageSource"
+	paramImageFormat = "imageFormat"
+	// Annotations of the PVC overriding the parameters of the StorageClass
+	annImageSource = "qsd.csi.com/image-source"
+	annImageFormat = "qsd.csi.com/image-format"
+)
+
+// transfer fills a volume in the background. The transfer outlives the
+// CreateVolume request, the CO retries the request until the transfer is done.
+type transfer struct {
+	done chan struct{}
+	err  error
+}
+
+// selectNode returns the node where the volume has to be created
+func selectNode(req *csi.TopologyRequirement) string {
+	for _, t := range append(req.GetPreferred(), req.GetRequisite()...) {
+		if node, ok := t.GetSegments()[TopologyKey]; ok {
+			return node
+		}
+	}
+	return defaultNode
+}
+
+// sourceNode returns the node where the snapshot or the volume source is stored
+func (d *Driver) sourceNode(source string) (string, bool) {
+	if s, ok := d.snapshots[source]; ok {
+		return s.node, true
+	}
+	if v, ok := d.storage[source]; ok {
+		return v.node, true
+	}
+	return "", false
+}
+
+// runTransfer starts fn in the background for the volume id if it isn't already
+// running, and waits for its termination until the request is cancelled
+func (d *Driver) runTransfer(ctx context.Context, id, desc string, fn func(context.Context) error) error {
+	d.transfersMu.Lock()
+	t, ok := d.transfers[id]
+	if !ok {
+		t = &transfer{done: make(chan struct{})}
+		d.transfers[id] = t
+		d.log.Infof("start %s", desc)
+		go func() {
+			defer close(t.done)
+			tctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+			defer cancel()
+			t.err = fn(tctx)
+			// The volume was filled by a previous request
+			if status.Code(t.err) == codes.AlreadyExists {
+				t.err = nil
+			}
+		}()
+	}
+	d.transfersMu.Unlock()
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return status.Errorf(codes.Aborted, "%s in progress", desc)
+	}
+	d.transfersMu.Lock()
+	delete(d.transfers, id)
+	d.transfersMu.Unlock()
+	if t.err != nil {
+		return status.Errorf(codes.Internal, "Error for the %s: %v", desc, t.err)
+	}
+	return nil
+}
+
+// copyFromNode creates the volume on node as a standalone copy of the source stored
+// on srcNode. The source node exports the source over NBD and the target node pulls
+// it with a mirror job.
+func (d *Driver) copyFromNode(ctx context.Context, srcNode, node, source string, image *qsd.Image) error {
+	src, err := d.qsdClient(ctx, srcNode)
+	if err != nil {
+		return err
+	}
+	dst, err := d.qsdClient(ctx, node)
+	if err != nil {
+		return err
+	}
+	host, err := d.qsdAddress(ctx, srcNode)
+	if err != nil {
+		return err
+	}
+	desc := fmt.Sprintf("copy of %s from node %s to node %s", source, srcNode, node)
+	return d.runTransfer(ctx, image.ID, desc, func(tctx context.Context) error {
+		return qsd.TransferVolume(tctx, src, dst, host, source, image)
+	})
+}
+
+// isURL returns if the image source is a http(s) URL
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// imageSource returns the image to import in the volume from the annotations of the
+// PVC or the parameters of the StorageClass. The annotations are set by the users and
+// only accept URLs, the local paths are reserved to the StorageClasses.
+func (d *Driver) imageSource(ctx context.Context, params map[string]string) (string, string, error) {
+	source, format := params[paramImageSource], params[paramImageFormat]
+	if d.kube == nil || params[paramPVCName] == "" {
+		return source, format, nil
+	}
+	pvc, err := d.kube.CoreV1().PersistentVolumeClaims(params[paramPVCNamespace]).Get(ctx, params[paramPVCName], metav1.GetOptions{})
+	if err != nil {
+		return "", "", status.Errorf(codes.Internal, "Failed getting the PVC %s/%s: %v", params[paramPVCNamespace], params[paramPVCName], err)
+	}
+	if s, ok := pvc.Annotations[annImageSource]; ok {
+		if !isURL(s) {
+			return "", "", status.Errorf(codes.InvalidArgument, "The annotation %s of the PVC %s/%s must be a http(s) URL", annImageSource, params[paramPVCNamespace], params[paramPVCName])
+		}
+		source, format = s, pvc.Annotations[annImageFormat]
+	}
+	return source, format, nil
+}
+
+// importImage creates the volume on node from the image source
+func (d *Driver) importImage(ctx context.Context, node, source, format string, image *qsd.Image) error {
+	client, err := d.qsdClient(ctx, node)
+	if err != nil {
+		return err
+	}
+	desc := fmt.Sprintf("import of %s on node %s", source, node)
+	return d.runTransfer(ctx, image.ID, desc, func(tctx context.Context) error {
+		stream, err := client.ImportVolume(tctx, &qsd.ImportParams{
+			VolumeID:     image.ID,
+			Source:       source,
+			Format:       format,
+			PVCName:      image.PVCName,
+			PVCNamespace: image.PVCNamespace,
+			Export:       image.Export,
+			IO:           image.IO,
+		})
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := stream.Recv(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// MigrateVolume copies the volume on another node and moves the node label of its
+// PV there. The volume must not be in use: the migration is rejected while the
+// volume is exported on the source, and the source refuses to export it during the
+// copy. As for the promotion, the PV needs to be re-created with the new node
+// affinity.
+func (d *Driver) MigrateVolume(ctx context.Context, req *csiext.MigrateVolumeRequest) (*csiext.MigrateVolumeResponse, error) {
+	id, node := req.GetVolumeId(), req.GetNodeId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "MigrateVolume Volume ID must be provided")
+	}
+	if node == "" {
+		return nil, status.Error(codes.InvalidArgument, "MigrateVolume Node ID must be provided")
+	}
+	log := d.log.WithFields(logrus.Fields{
+		"volume_id": id,
+		"node_id":   node,
+		"method":    "controller_migrate_volume",
+	})
+	v, ok := d.storage[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Volume %s not found in the storage", id)
+	}
+	// Retry of a completed migration
+	if v.node == node {
+		return &csiext.MigrateVolumeResponse{NodeId: node}, nil
+	}
+	if v.replica != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "Volume %s is replicated on %s and cannot be migrated", id, v.replica)
+	}
+	if err := d.checkNotExported(ctx, v); err != nil {
+		return nil, err
+	}
+	if err := d.copyFromNode(ctx, v.node, node, id, &qsd.Image{ID: id}); err != nil {
+		return nil, err
+	}
+	if err := d.moveVolume(ctx, id, v.node, node); err != nil {
+		return nil, err
+	}
+	if req.GetDeleteSource() {
+		if err := d.deleteSource(ctx, v); err != nil {
+			return nil, err
+		}
+	}
+	source := v.node
+	v.node = node
+	d.storage[id] = v
+	log.Infof("volume migrated from %s", source)
+	return &csiext.MigrateVolumeResponse{NodeId: node}, nil
+}
+
+// checkNotExported returns FailedPrecondition if the volume is exported to a VM
+func (d *Driver) checkNotExported(ctx context.Context, v Volume) error {
+	client, err := d.qsdClient(ctx, v.node)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	resp, err := client.ListVolumes(callCtx, &qsd.ListVolumesParams{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error for listing the volumes on %s: %v", v.node, err)
+	}
+	for _, vol := range resp.Volumes {
+		if vol.VolumeRef == v.id && vol.Exports > 0 {
+			return status.Errorf(codes.FailedPrecondition, "Volume %s is in use on %s", v.id, v.node)
+		}
+	}
+	return nil
+}
+
+// deleteSource removes the volume left on its previous node by a migration
+func (d *Driver) deleteSource(ctx context.Context, v Volume) error {
+	client, err := d.qsdClient(ctx, v.node)
+	if err != nil {
+		return err
+	}
+	callCtx, cancel := callContext(ctx)
+	defer cancel()
+	if _, err := client.DeleteExporter(callCtx, &qsd.Image{ID: v.id}); err != nil {
+		return status.Errorf(codes.Internal, "Error for deleting the exporter on %s: %v", v.node, err)
+	}
+	if _, err := client.DeleteVolume(callCtx, &qsd.Image{ID: v.id}); err != nil {
+		return status.Errorf(codes.Internal, "Error for deleting the volume on %s: %v", v.node, err)
+	}
+	return nil
+}
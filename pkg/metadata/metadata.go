@@ -20,6 +20,9 @@ const (
 	AnnQSDID          = prefixAnn + "/qsdID"
 	AnnBackingImageID = prefixAnn + "/backingImageID"
 	AnnRefCount       = prefixAnn + "/refCount"
+	AnnReplicaNode    = prefixAnn + "/replicaNode"
+	// AttrReplicaNode is the volume attribute with the replica node set by the driver
+	AttrReplicaNode = "replicaNode"
 )
 
 type MetadataServer struct {
@@ -90,11 +93,17 @@ func parseAnnotationsFromPVtoMetadata(pv corev1.PersistentVolume) (*Metadata, er
 			return nil, err
 		}
 	}
+	// The annotation is updated by the moves, the attribute is only set at creation
+	replica, okReplica := pv.ObjectMeta.Annotations[AnnReplicaNode]
+	if !okReplica && pv.Spec.CSI != nil {
+		replica = pv.Spec.CSI.VolumeAttributes[AttrReplicaNode]
+	}
 	return &Metadata{
 		ID:             id,
 		QSDID:          qsdID,
 		RefCount:       refCount,
 		BackingImageID: bID,
+		ReplicaNode:    replica,
 	}, nil
 }
 
@@ -145,6 +154,9 @@ func (s *MetadataServer) AddMetadata(_ context.Context, m *Metadata) (*ResponseA
 	pv.ObjectMeta.Annotations[AnnQSDID] = m.QSDID
 	pv.ObjectMeta.Annotations[AnnBackingImageID] = m.BackingImageID
 	pv.ObjectMeta.Annotations[AnnRefCount] = strconv.FormatInt(m.RefCount, 10)
+	if m.ReplicaNode != "" {
+		pv.ObjectMeta.Annotations[AnnReplicaNode] = m.ReplicaNode
+	}
 
 	if _, err := s.Client.CoreV1().PersistentVolumes().Update(context.TODO(), pv, metav1.UpdateOptions{}); err != nil {
 		return &ResponseAddMetadata{}, err
@@ -152,3 +164,35 @@ func (s *MetadataServer) AddMetadata(_ context.Context, m *Metadata) (*ResponseA
 
 	return &ResponseAddMetadata{}, nil
 }
+
+// MoveVolume changes the node label of the PV after the volume has been migrated
+// to another node. The move is refused if the PV is labeled with a different node
+// than FromNode.
+func (s *MetadataServer) MoveVolume(ctx context.Context, p *MoveVolumeParams) (*ResponseMoveVolume, error) {
+	pv, err := s.Client.CoreV1().PersistentVolumes().Get(ctx, p.ID, metav1.GetOptions{})
+	if err != nil {
+		return &ResponseMoveVolume{}, err
+	}
+	if pv.ObjectMeta.Labels == nil {
+		pv.ObjectMeta.Labels = make(map[string]string)
+	}
+	if v, ok := pv.ObjectMeta.Labels[NodeLabel]; ok && v != p.FromNode && v != p.ToNode {
+		return &ResponseMoveVolume{}, fmt.Errorf("Volume %s is on the node %s and not on %s", p.ID, v, p.FromNode)
+	}
+	pv.ObjectMeta.Labels[NodeLabel] = p.ToNode
+	// The promoted replica isn't replicated anymore
+	replica, ok := pv.ObjectMeta.Annotations[AnnReplicaNode]
+	if !ok && pv.Spec.CSI != nil {
+		replica = pv.Spec.CSI.VolumeAttributes[AttrReplicaNode]
+	}
+	if replica != "" && replica == p.ToNode {
+		if pv.ObjectMeta.Annotations == nil {
+			pv.ObjectMeta.Annotations = make(map[string]string)
+		}
+		pv.ObjectMeta.Annotations[AnnReplicaNode] = ""
+	}
+	if _, err := s.Client.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{}); err != nil {
+		return &ResponseMoveVolume{}, err
+	}
+	return &ResponseMoveVolume{}, nil
+}
@@ -63,6 +63,107 @@ func (*ResponseAddMetadata) Descriptor() ([]byte, []int) {
 	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{0}
 }
 
+type MoveVolumeParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID       string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	FromNode string `protobuf:"bytes,2,opt,name=FromNode,proto3" json:"FromNode,omitempty"`
+	ToNode   string `protobuf:"bytes,3,opt,name=ToNode,proto3" json:"ToNode,omitempty"`
+}
+
+func (x *MoveVolumeParams) Reset() {
+	*x = MoveVolumeParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metadata_metadata_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveVolumeParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveVolumeParams) ProtoMessage() {}
+
+func (x *MoveVolumeParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metadata_metadata_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveVolumeParams.ProtoReflect.Descriptor instead.
+func (*MoveVolumeParams) Descriptor() ([]byte, []int) {
+	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MoveVolumeParams) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *MoveVolumeParams) GetFromNode() string {
+	if x != nil {
+		return x.FromNode
+	}
+	return ""
+}
+
+func (x *MoveVolumeParams) GetToNode() string {
+	if x != nil {
+		return x.ToNode
+	}
+	return ""
+}
+
+type ResponseMoveVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResponseMoveVolume) Reset() {
+	*x = ResponseMoveVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_metadata_metadata_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseMoveVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseMoveVolume) ProtoMessage() {}
+
+func (x *ResponseMoveVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_metadata_metadata_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseMoveVolume.ProtoReflect.Descriptor instead.
+func (*ResponseMoveVolume) Descriptor() ([]byte, []int) {
+	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{2}
+}
+
 type ResponseGetVolumes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -74,7 +175,7 @@ type ResponseGetVolumes struct {
 func (x *ResponseGetVolumes) Reset() {
 	*x = ResponseGetVolumes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metadata_metadata_proto_msgTypes[1]
+		mi := &file_pkg_metadata_metadata_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -87,7 +188,7 @@ func (x *ResponseGetVolumes) String() string {
 func (*ResponseGetVolumes) ProtoMessage() {}
 
 func (x *ResponseGetVolumes) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metadata_metadata_proto_msgTypes[1]
+	mi := &file_pkg_metadata_metadata_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -100,7 +201,7 @@ func (x *ResponseGetVolumes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetVolumes.ProtoReflect.Descriptor instead.
 func (*ResponseGetVolumes) Descriptor() ([]byte, []int) {
-	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{1}
+	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ResponseGetVolumes) GetVolumes() []*Metadata {
@@ -121,7 +222,7 @@ type Node struct {
 func (x *Node) Reset() {
 	*x = Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metadata_metadata_proto_msgTypes[2]
+		mi := &file_pkg_metadata_metadata_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -134,7 +235,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metadata_metadata_proto_msgTypes[2]
+	mi := &file_pkg_metadata_metadata_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,7 +248,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{2}
+	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Node) GetNodeID() string {
@@ -167,12 +268,14 @@ type Metadata struct {
 	RefCount       int64  `protobuf:"varint,3,opt,name=RefCount,proto3" json:"RefCount,omitempty"`
 	BackingImageID string `protobuf:"bytes,4,opt,name=BackingImageID,proto3" json:"BackingImageID,omitempty"`
 	Node           string `protobuf:"bytes,5,opt,name=Node,proto3" json:"Node,omitempty"`
+	// ReplicaNode keeps the synchronous replica of the volume
+	ReplicaNode string `protobuf:"bytes,6,opt,name=ReplicaNode,proto3" json:"ReplicaNode,omitempty"`
 }
 
 func (x *Metadata) Reset() {
 	*x = Metadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_metadata_metadata_proto_msgTypes[3]
+		mi := &file_pkg_metadata_metadata_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -185,7 +288,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_metadata_metadata_proto_msgTypes[3]
+	mi := &file_pkg_metadata_metadata_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +301,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{3}
+	return file_pkg_metadata_metadata_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Metadata) GetID() string {
@@ -236,6 +339,13 @@ func (x *Metadata) GetNode() string {
 	return ""
 }
 
+func (x *Metadata) GetReplicaNode() string {
+	if x != nil {
+		return x.ReplicaNode
+	}
+	return ""
+}
+
 var File_pkg_metadata_metadata_proto protoreflect.FileDescriptor
 
 var file_pkg_metadata_metadata_proto_rawDesc = []byte{
@@ -243,23 +353,32 @@ var file_pkg_metadata_metadata_proto_rawDesc = []byte{
 	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61,
 	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
 	0x73, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x64,
-	0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4d, 0x0a, 0x12, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12,
-	0x37, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
-	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x22, 0x88, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x52,
-	0x65, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52,
-	0x65, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69,
-	0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x12,
-	0x12, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
-	0x6f, 0x64, 0x65, 0x32, 0xbf, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x56, 0x0a, 0x10, 0x4d, 0x6f, 0x76,
+	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x0e, 0x0a,
+	0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x6f, 0x4e,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x6f, 0x4e, 0x6f, 0x64,
+	0x65, 0x22, 0x14, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x6f, 0x76,
+	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x4d, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x37, 0x0a,
+	0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67,
+	0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x07, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x22, 0xaa, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x66,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x66,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x42,
+	0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4e, 0x6f, 0x64, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4e,
+	0x6f, 0x64, 0x65, 0x32, 0x9f, 0x02, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x6f,
 	0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
 	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
@@ -271,7 +390,13 @@ var file_pkg_metadata_metadata_proto_rawDesc = []byte{
 	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x28, 0x2e, 0x61, 0x6c, 0x69, 0x63,
 	0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x64, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x61, 0x74, 0x61, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x25, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73,
+	0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x27, 0x2e, 0x61, 0x6c, 0x69,
+	0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
 	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2f, 0x63, 0x73, 0x69, 0x2d,
 	0x71, 0x73, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
@@ -289,21 +414,25 @@ func file_pkg_metadata_metadata_proto_rawDescGZIP() []byte {
 	return file_pkg_metadata_metadata_proto_rawDescData
 }
 
-var file_pkg_metadata_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pkg_metadata_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_pkg_metadata_metadata_proto_goTypes = []interface{}{
 	(*ResponseAddMetadata)(nil), // 0: alicefr.csi.pkg.qsd.ResponseAddMetadata
-	(*ResponseGetVolumes)(nil),  // 1: alicefr.csi.pkg.qsd.ResponseGetVolumes
-	(*Node)(nil),                // 2: alicefr.csi.pkg.qsd.Node
-	(*Metadata)(nil),            // 3: alicefr.csi.pkg.qsd.Metadata
+	(*MoveVolumeParams)(nil),    // 1: alicefr.csi.pkg.qsd.MoveVolumeParams
+	(*ResponseMoveVolume)(nil),  // 2: alicefr.csi.pkg.qsd.ResponseMoveVolume
+	(*ResponseGetVolumes)(nil),  // 3: alicefr.csi.pkg.qsd.ResponseGetVolumes
+	(*Node)(nil),                // 4: alicefr.csi.pkg.qsd.Node
+	(*Metadata)(nil),            // 5: alicefr.csi.pkg.qsd.Metadata
 }
 var file_pkg_metadata_metadata_proto_depIdxs = []int32{
-	3, // 0: alicefr.csi.pkg.qsd.ResponseGetVolumes.volumes:type_name -> alicefr.csi.pkg.qsd.Metadata
-	2, // 1: alicefr.csi.pkg.qsd.MetadataService.GetVolumes:input_type -> alicefr.csi.pkg.qsd.Node
-	3, // 2: alicefr.csi.pkg.qsd.MetadataService.AddMetadata:input_type -> alicefr.csi.pkg.qsd.Metadata
-	1, // 3: alicefr.csi.pkg.qsd.MetadataService.GetVolumes:output_type -> alicefr.csi.pkg.qsd.ResponseGetVolumes
-	0, // 4: alicefr.csi.pkg.qsd.MetadataService.AddMetadata:output_type -> alicefr.csi.pkg.qsd.ResponseAddMetadata
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
+	5, // 0: alicefr.csi.pkg.qsd.ResponseGetVolumes.volumes:type_name -> alicefr.csi.pkg.qsd.Metadata
+	4, // 1: alicefr.csi.pkg.qsd.MetadataService.GetVolumes:input_type -> alicefr.csi.pkg.qsd.Node
+	5, // 2: alicefr.csi.pkg.qsd.MetadataService.AddMetadata:input_type -> alicefr.csi.pkg.qsd.Metadata
+	1, // 3: alicefr.csi.pkg.qsd.MetadataService.MoveVolume:input_type -> alicefr.csi.pkg.qsd.MoveVolumeParams
+	3, // 4: alicefr.csi.pkg.qsd.MetadataService.GetVolumes:output_type -> alicefr.csi.pkg.qsd.ResponseGetVolumes
+	0, // 5: alicefr.csi.pkg.qsd.MetadataService.AddMetadata:output_type -> alicefr.csi.pkg.qsd.ResponseAddMetadata
+	2, // 6: alicefr.csi.pkg.qsd.MetadataService.MoveVolume:output_type -> alicefr.csi.pkg.qsd.ResponseMoveVolume
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -328,7 +457,7 @@ func file_pkg_metadata_metadata_proto_init() {
 			}
 		}
 		file_pkg_metadata_metadata_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetVolumes); i {
+			switch v := v.(*MoveVolumeParams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -340,7 +469,7 @@ func file_pkg_metadata_metadata_proto_init() {
 			}
 		}
 		file_pkg_metadata_metadata_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
+			switch v := v.(*ResponseMoveVolume); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -352,6 +481,30 @@ func file_pkg_metadata_metadata_proto_init() {
 			}
 		}
 		file_pkg_metadata_metadata_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseGetVolumes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metadata_metadata_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_metadata_metadata_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Metadata); i {
 			case 0:
 				return &v.state
@@ -370,7 +523,7 @@ func file_pkg_metadata_metadata_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_metadata_metadata_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
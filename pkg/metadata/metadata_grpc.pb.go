@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion7
 type MetadataServiceClient interface {
 	GetVolumes(ctx context.Context, in *Node, opts ...grpc.CallOption) (*ResponseGetVolumes, error)
 	AddMetadata(ctx context.Context, in *Metadata, opts ...grpc.CallOption) (*ResponseAddMetadata, error)
+	MoveVolume(ctx context.Context, in *MoveVolumeParams, opts ...grpc.CallOption) (*ResponseMoveVolume, error)
 }
 
 type metadataServiceClient struct {
@@ -48,12 +49,22 @@ func (c *metadataServiceClient) AddMetadata(ctx context.Context, in *Metadata, o
 	return out, nil
 }
 
+func (c *metadataServiceClient) MoveVolume(ctx context.Context, in *MoveVolumeParams, opts ...grpc.CallOption) (*ResponseMoveVolume, error) {
+	out := new(ResponseMoveVolume)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.MetadataService/MoveVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetadataServiceServer is the server API for MetadataService service.
 // All implementations must embed UnimplementedMetadataServiceServer
 // for forward compatibility
 type MetadataServiceServer interface {
 	GetVolumes(context.Context, *Node) (*ResponseGetVolumes, error)
 	AddMetadata(context.Context, *Metadata) (*ResponseAddMetadata, error)
+	MoveVolume(context.Context, *MoveVolumeParams) (*ResponseMoveVolume, error)
 	mustEmbedUnimplementedMetadataServiceServer()
 }
 
@@ -67,6 +78,9 @@ func (UnimplementedMetadataServiceServer) GetVolumes(context.Context, *Node) (*R
 func (UnimplementedMetadataServiceServer) AddMetadata(context.Context, *Metadata) (*ResponseAddMetadata, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddMetadata not implemented")
 }
+func (UnimplementedMetadataServiceServer) MoveVolume(context.Context, *MoveVolumeParams) (*ResponseMoveVolume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveVolume not implemented")
+}
 func (UnimplementedMetadataServiceServer) mustEmbedUnimplementedMetadataServiceServer() {}
 
 // UnsafeMetadataServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +130,24 @@ func _MetadataService_AddMetadata_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_MoveVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveVolumeParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).MoveVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.MetadataService/MoveVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).MoveVolume(ctx, req.(*MoveVolumeParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MetadataService_ServiceDesc is the grpc.ServiceDesc for MetadataService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +163,10 @@ var MetadataService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddMetadata",
 			Handler:    _MetadataService_AddMetadata_Handler,
 		},
+		{
+			MethodName: "MoveVolume",
+			Handler:    _MetadataService_MoveVolume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/metadata/metadata.proto",
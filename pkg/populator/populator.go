@@ -0,0 +1,353 @@
+package populator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	Group = "qsd.csi.com"
+	Kind  = "QsdImageSource"
+
+	// primePrefix is the prefix of the PVCs provisioned by the populator
+	primePrefix = "populate-"
+	// finalizer protects the PVC until the prime PVC has been removed
+	finalizer = "qsd.csi.com/populate-target-protection"
+	// annSelectedNode is set by the scheduler on the PVCs of the storage classes
+	// with WaitForFirstConsumer binding mode
+	annSelectedNode = "volume.kubernetes.io/selected-node"
+	// Annotations read by the driver to import the image in the new volume
+	annImageSource = "qsd.csi.com/image-source"
+	annImageFormat = "qsd.csi.com/image-format"
+
+	resyncPeriod = 10 * time.Minute
+)
+
+// ImageSourceResource is the custom resource referenced by the dataSourceRef of the PVCs
+var ImageSourceResource = schema.GroupVersionResource{
+	Group:    Group,
+	Version:  "v1alpha1",
+	Resource: "qsdimagesources",
+}
+
+// Populator fills the PVCs with a QsdImageSource data source. A prime PVC with the
+// annotations of the image is created in the namespace of the populator, the driver
+// imports the image while provisioning it, and the PV is then rebound to the
+// original PVC.
+type Populator struct {
+	client     kubernetes.Interface
+	dynClient  dynamic.Interface
+	namespace  string
+	driverName string
+	factory    informers.SharedInformerFactory
+	pvcLister  corelisters.PersistentVolumeClaimLister
+	pvLister   corelisters.PersistentVolumeLister
+	synced     []cache.InformerSynced
+	queue      workqueue.RateLimitingInterface
+	recorder   record.EventRecorder
+}
+
+func NewPopulator(client kubernetes.Interface, dynClient dynamic.Interface, namespace, driverName string) *Populator {
+	factory := informers.NewSharedInformerFactory(client, resyncPeriod)
+	pvcInformer := factory.Core().V1().PersistentVolumeClaims()
+	pvInformer := factory.Core().V1().PersistentVolumes()
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	p := &Populator{
+		client:     client,
+		dynClient:  dynClient,
+		namespace:  namespace,
+		driverName: driverName,
+		factory:    factory,
+		pvcLister:  pvcInformer.Lister(),
+		pvLister:   pvInformer.Lister(),
+		synced:     []cache.InformerSynced{pvcInformer.Informer().HasSynced, pvInformer.Informer().HasSynced},
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "populator"),
+		recorder:   broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "qsd-populator"}),
+	}
+	pvcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    p.enqueuePVC,
+		UpdateFunc: func(_, obj interface{}) { p.enqueuePVC(obj) },
+		DeleteFunc: p.enqueuePVC,
+	})
+	pvInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    p.enqueuePV,
+		UpdateFunc: func(_, obj interface{}) { p.enqueuePV(obj) },
+	})
+	return p
+}
+
+// enqueuePVC queues the PVCs to populate, the changes of the prime PVCs are
+// reported to their original PVC
+func (p *Populator) enqueuePVC(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pvc, ok := obj.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return
+	}
+	if pvc.Namespace == p.namespace {
+		if key, ok := pvc.Annotations[annPopulateFor]; ok {
+			p.queue.Add(key)
+			return
+		}
+	}
+	if isImageSource(pvc) {
+		p.queue.Add(pvc.Namespace + "/" + pvc.Name)
+	}
+}
+
+func (p *Populator) enqueuePV(obj interface{}) {
+	pv, ok := obj.(*corev1.PersistentVolume)
+	if !ok || pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != p.namespace {
+		return
+	}
+	if pvc, err := p.pvcLister.PersistentVolumeClaims(p.namespace).Get(pv.Spec.ClaimRef.Name); err == nil {
+		p.enqueuePVC(pvc)
+	}
+}
+
+// annPopulateFor is set on the prime PVCs with the key of the original PVC
+const annPopulateFor = "qsd.csi.com/populate-for"
+
+func isImageSource(pvc *corev1.PersistentVolumeClaim) bool {
+	ref := pvc.Spec.DataSourceRef
+	return ref != nil && ref.APIGroup != nil && *ref.APIGroup == Group && ref.Kind == Kind
+}
+
+func primeName(pvc *corev1.PersistentVolumeClaim) string {
+	return primePrefix + string(pvc.UID)
+}
+
+// Run starts the workers and blocks until the context is cancelled
+func (p *Populator) Run(ctx context.Context, workers int) error {
+	defer utilruntime.HandleCrash()
+	defer p.queue.ShutDown()
+	p.factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), p.synced...) {
+		return fmt.Errorf("failed waiting for the caches to sync")
+	}
+	log.Infof("Populator started")
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, p.runWorker, time.Second)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (p *Populator) runWorker(ctx context.Context) {
+	for p.processNext(ctx) {
+	}
+}
+
+func (p *Populator) processNext(ctx context.Context) bool {
+	obj, shutdown := p.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer p.queue.Done(obj)
+	key := obj.(string)
+	if err := p.sync(ctx, key); err != nil {
+		log.Errorf("Failed populating the PVC %s: %v", key, err)
+		p.queue.AddRateLimited(key)
+		return true
+	}
+	p.queue.Forget(key)
+	return true
+}
+
+func (p *Populator) sync(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	pvc, err := p.pvcLister.PersistentVolumeClaims(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !isImageSource(pvc) {
+		return nil
+	}
+	// The PVC is bound or deleted, only the prime PVC needs to be cleaned up
+	if pvc.Spec.VolumeName != "" || pvc.DeletionTimestamp != nil {
+		return p.cleanup(ctx, pvc)
+	}
+	if pvc.Spec.StorageClassName == nil {
+		return nil
+	}
+	sc, err := p.client.StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if sc.Provisioner != p.driverName {
+		return nil
+	}
+	// Wait for the scheduler to pick the node of the consumer
+	selectedNode := pvc.Annotations[annSelectedNode]
+	if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer && selectedNode == "" {
+		return nil
+	}
+	source, format, err := p.imageSource(ctx, pvc)
+	if err != nil {
+		p.recorder.Eventf(pvc, corev1.EventTypeWarning, "PopulateFailed", "Failed getting the image source: %v", err)
+		return err
+	}
+	if err := p.addFinalizer(ctx, pvc); err != nil {
+		return err
+	}
+
+	prime, err := p.pvcLister.PersistentVolumeClaims(p.namespace).Get(primeName(pvc))
+	if errors.IsNotFound(err) {
+		prime, err = p.createPrime(ctx, pvc, selectedNode, source, format)
+		if err != nil {
+			return err
+		}
+		p.recorder.Eventf(pvc, corev1.EventTypeNormal, "Populating", "Populating the volume from %s", source)
+	}
+	if err != nil {
+		return err
+	}
+	// The driver imports the image while provisioning the prime PVC
+	if prime.Spec.VolumeName == "" || prime.Status.Phase != corev1.ClaimBound {
+		return nil
+	}
+	pv, err := p.pvLister.Get(prime.Spec.VolumeName)
+	if err != nil {
+		return err
+	}
+	if pv.Spec.ClaimRef != nil && pv.Spec.ClaimRef.UID == pvc.UID {
+		// Already rebound, wait for the binding of the PVC
+		return nil
+	}
+	return p.rebind(ctx, pv, pvc)
+}
+
+// imageSource reads the image of the QsdImageSource referenced by the PVC
+func (p *Populator) imageSource(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (string, string, error) {
+	u, err := p.dynClient.Resource(ImageSourceResource).Namespace(pvc.Namespace).Get(ctx, pvc.Spec.DataSourceRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	source, _, err := unstructured.NestedString(u.Object, "spec", "url")
+	if err != nil {
+		return "", "", err
+	}
+	if source == "" {
+		return "", "", fmt.Errorf("%s %s/%s has no url", Kind, pvc.Namespace, pvc.Spec.DataSourceRef.Name)
+	}
+	// The QsdImageSources are owned by the users, like the PVC annotations the
+	// driver only accepts URLs from them
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return "", "", fmt.Errorf("%s %s/%s: url %q must be http or https", Kind, pvc.Namespace, pvc.Spec.DataSourceRef.Name, source)
+	}
+	format, _, err := unstructured.NestedString(u.Object, "spec", "format")
+	if err != nil {
+		return "", "", err
+	}
+	return source, format, nil
+}
+
+func (p *Populator) createPrime(ctx context.Context, pvc *corev1.PersistentVolumeClaim, selectedNode, source, format string) (*corev1.PersistentVolumeClaim, error) {
+	annotations := map[string]string{
+		annPopulateFor: pvc.Namespace + "/" + pvc.Name,
+		annImageSource: source,
+	}
+	if format != "" {
+		annotations[annImageFormat] = format
+	}
+	if selectedNode != "" {
+		annotations[annSelectedNode] = selectedNode
+	}
+	prime := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        primeName(pvc),
+			Namespace:   p.namespace,
+			Annotations: annotations,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        pvc.Spec.Resources,
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+		},
+	}
+	log.Infof("Create the prime PVC %s/%s for %s/%s", p.namespace, prime.Name, pvc.Namespace, pvc.Name)
+	return p.client.CoreV1().PersistentVolumeClaims(p.namespace).Create(ctx, prime, metav1.CreateOptions{})
+}
+
+// rebind moves the PV of the prime PVC to the original PVC. The PV controller binds
+// the PVC and releases the prime PVC.
+func (p *Populator) rebind(ctx context.Context, pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) error {
+	pv = pv.DeepCopy()
+	pv.Spec.ClaimRef = &corev1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		Namespace:  pvc.Namespace,
+		Name:       pvc.Name,
+		UID:        pvc.UID,
+		APIVersion: "v1",
+	}
+	log.Infof("Bind the PV %s to %s/%s", pv.Name, pvc.Namespace, pvc.Name)
+	if _, err := p.client.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	p.recorder.Eventf(pvc, corev1.EventTypeNormal, "Populated", "Volume populated in %s", pv.Name)
+	return nil
+}
+
+func (p *Populator) addFinalizer(ctx context.Context, pvc *corev1.PersistentVolumeClaim) error {
+	for _, f := range pvc.Finalizers {
+		if f == finalizer {
+			return nil
+		}
+	}
+	pvc = pvc.DeepCopy()
+	pvc.Finalizers = append(pvc.Finalizers, finalizer)
+	_, err := p.client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanup deletes the prime PVC and removes the finalizer of the PVC
+func (p *Populator) cleanup(ctx context.Context, pvc *corev1.PersistentVolumeClaim) error {
+	err := p.client.CoreV1().PersistentVolumeClaims(p.namespace).Delete(ctx, primeName(pvc), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	var finalizers []string
+	for _, f := range pvc.Finalizers {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if len(finalizers) == len(pvc.Finalizers) {
+		return nil
+	}
+	pvc = pvc.DeepCopy()
+	pvc.Finalizers = finalizers
+	_, err = p.client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+	return err
+}
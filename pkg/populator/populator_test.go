@@ -0,0 +1,224 @@
+package populator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	testNamespace = "qsd"
+	testDriver    = "qsd.csi.com"
+)
+
+func newPVC() *corev1.PersistentVolumeClaim {
+	group := Group
+	sc := "qsd"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "disk",
+			Namespace: "default",
+			UID:       "1234",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			StorageClassName: &sc,
+			DataSourceRef: &corev1.TypedLocalObjectReference{
+				APIGroup: &group,
+				Kind:     Kind,
+				Name:     "image",
+			},
+		},
+	}
+}
+
+func newImageSource(url string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": Group + "/v1alpha1",
+		"kind":       Kind,
+		"metadata": map[string]interface{}{
+			"name":      "image",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"url":    url,
+			"format": "qcow2",
+		},
+	}}
+}
+
+func newStorageClass(mode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: "qsd"},
+		Provisioner:       testDriver,
+		VolumeBindingMode: &mode,
+	}
+}
+
+// newTestPopulator returns a populator with the objects in the fake clients and
+// the PVCs and PVs in the caches of the listers
+func newTestPopulator(t *testing.T, objects []runtime.Object, sources ...runtime.Object) (*Populator, *fake.Clientset) {
+	client := fake.NewSimpleClientset(objects...)
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), sources...)
+	p := NewPopulator(client, dynClient, testNamespace, testDriver)
+	p.recorder = record.NewFakeRecorder(10)
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *corev1.PersistentVolumeClaim:
+			err = p.factory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer().Add(o)
+		case *corev1.PersistentVolume:
+			err = p.factory.Core().V1().PersistentVolumes().Informer().GetIndexer().Add(o)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p, client
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		mode         storagev1.VolumeBindingMode
+		selectedNode string
+		prime        bool
+		finalizer    bool
+		fail         bool
+	}{
+		{name: "immediate", url: "https://example.com/disk.qcow2", mode: storagev1.VolumeBindingImmediate, prime: true, finalizer: true},
+		{name: "selected node", url: "http://example.com/disk.qcow2", mode: storagev1.VolumeBindingWaitForFirstConsumer, selectedNode: "node1", prime: true, finalizer: true},
+		{name: "wait for the consumer", url: "https://example.com/disk.qcow2", mode: storagev1.VolumeBindingWaitForFirstConsumer},
+		{name: "local path", url: "/var/lib/kubelet/disk.qcow2", mode: storagev1.VolumeBindingImmediate, fail: true},
+		{name: "file url", url: "file:///etc/shadow", mode: storagev1.VolumeBindingImmediate, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := newPVC()
+			if tt.selectedNode != "" {
+				pvc.Annotations = map[string]string{annSelectedNode: tt.selectedNode}
+			}
+			p, client := newTestPopulator(t, []runtime.Object{pvc, newStorageClass(tt.mode)}, newImageSource(tt.url))
+			err := p.sync(context.Background(), "default/disk")
+			if (err != nil) != tt.fail {
+				t.Fatalf("sync error %v, want failure %v", err, tt.fail)
+			}
+
+			prime, err := client.CoreV1().PersistentVolumeClaims(testNamespace).Get(context.Background(), primeName(pvc), metav1.GetOptions{})
+			if !tt.prime {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("unexpected prime PVC: %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("prime PVC not created: %v", err)
+				}
+				if got := prime.Annotations[annImageSource]; got != tt.url {
+					t.Errorf("image source %q, want %q", got, tt.url)
+				}
+				if got := prime.Annotations[annPopulateFor]; got != "default/disk" {
+					t.Errorf("populate for %q, want default/disk", got)
+				}
+				if got := prime.Annotations[annSelectedNode]; got != tt.selectedNode {
+					t.Errorf("selected node %q, want %q", got, tt.selectedNode)
+				}
+			}
+
+			got, err := client.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "disk", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if has := len(got.Finalizers) == 1 && got.Finalizers[0] == finalizer; has != tt.finalizer {
+				t.Errorf("finalizers %v, want finalizer %v", got.Finalizers, tt.finalizer)
+			}
+		})
+	}
+}
+
+func TestSyncRebind(t *testing.T) {
+	pvc := newPVC()
+	pvc.Finalizers = []string{finalizer}
+	prime := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        primeName(pvc),
+			Namespace:   testNamespace,
+			Annotations: map[string]string{annPopulateFor: "default/disk"},
+		},
+		Spec:   corev1.PersistentVolumeClaimSpec{VolumeName: "pv1"},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv1"},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: testNamespace, Name: prime.Name, UID: "5678"},
+		},
+	}
+	objects := []runtime.Object{pvc, prime, pv, newStorageClass(storagev1.VolumeBindingImmediate)}
+	p, client := newTestPopulator(t, objects, newImageSource("https://example.com/disk.qcow2"))
+	if err := p.sync(context.Background(), "default/disk"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.CoreV1().PersistentVolumes().Get(context.Background(), "pv1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := got.Spec.ClaimRef
+	if ref == nil || ref.Namespace != "default" || ref.Name != "disk" || ref.UID != pvc.UID {
+		t.Errorf("claim ref %+v, want default/disk %s", ref, pvc.UID)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		name       string
+		finalizers []string
+		prime      bool
+		want       []string
+	}{
+		{name: "bound", finalizers: []string{finalizer}, prime: true},
+		{name: "other finalizers", finalizers: []string{"kubernetes.io/pvc-protection", finalizer}, prime: true, want: []string{"kubernetes.io/pvc-protection"}},
+		{name: "prime already deleted", finalizers: []string{finalizer}},
+		{name: "no finalizer", finalizers: []string{"kubernetes.io/pvc-protection"}, want: []string{"kubernetes.io/pvc-protection"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := newPVC()
+			pvc.Spec.VolumeName = "pv1"
+			pvc.Finalizers = tt.finalizers
+			objects := []runtime.Object{pvc}
+			if tt.prime {
+				objects = append(objects, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: primeName(pvc), Namespace: testNamespace},
+				})
+			}
+			p, client := newTestPopulator(t, objects)
+			if err := p.sync(context.Background(), "default/disk"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.CoreV1().PersistentVolumeClaims(testNamespace).Get(context.Background(), primeName(pvc), metav1.GetOptions{}); !errors.IsNotFound(err) {
+				t.Errorf("prime PVC not deleted: %v", err)
+			}
+			got, err := client.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "disk", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Finalizers) != len(tt.want) || (len(tt.want) > 0 && got.Finalizers[0] != tt.want[0]) {
+				t.Errorf("finalizers %v, want %v", got.Finalizers, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alicefr/csi-qsd/pkg/backup"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const backupsDir = "/var/run/qsd/backups"
+
+// backupState records the last backup of a volume and the node where the bitmap
+// tracks the writes since then
+type backupState struct {
+	Last string
+	Node string
+}
+
+// SetUploader configures where the backups are stored
+func (c *Server) SetUploader(u backup.Uploader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uploader = u
+}
+
+func failedBackup(m string, err error) (*ResponseBackup, error) {
+	log.Errorf(m)
+	return &ResponseBackup{
+		Success: false,
+		Message: m,
+	}, err
+}
+
+// startBackup reserves the volume for a backup or a restore
+func (c *Server) startBackup(id string) error {
+	if c.uploader == nil {
+		return status.Error(codes.FailedPrecondition, "The backup target is not configured")
+	}
+	return c.reserve(id)
+}
+
+// reserve marks the volume as busy for the operations running without holding the lock
+func (c *Server) reserve(id string) error {
+	if c.busy[id] {
+		return status.Errorf(codes.Aborted, "A backup, restore, migration or merge for %s is already in progress", id)
+	}
+	c.busy[id] = true
+	return nil
+}
+
+func (c *Server) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.busy, id)
+}
+
+func hasBitmap(n *NameBlockNode, name string) bool {
+	for _, b := range n.DirtyBitmaps {
+		if b.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// BackupVolume copies the active layer of the volume in a local image and uploads it
+// with the uploader. Incremental backups only contain the clusters written since the
+// previous backup. When the previous backup is not usable, for example because the
+// bitmap has been lost, a full backup is taken instead.
+func (c *Server) BackupVolume(ctx context.Context, p *BackupParams) (*ResponseBackup, error) {
+	log.Infof("Backup volume %s", p.VolumeID)
+	if p.BackupID == "" {
+		errMessage := "The backup ID cannot be empty"
+		return failedBackup(errMessage, status.Error(codes.InvalidArgument, errMessage))
+	}
+	c.mu.Lock()
+	a, ok := c.images[c.activeLayers[p.VolumeID]]
+	if !ok {
+		c.mu.Unlock()
+		errMessage := fmt.Sprintf("Volume %s not found", p.VolumeID)
+		return failedBackup(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if err := c.startBackup(p.VolumeID); err != nil {
+		c.mu.Unlock()
+		return failedBackup(err.Error(), err)
+	}
+	defer c.release(p.VolumeID)
+	var prev backupState
+	if b, ok := c.backups[p.VolumeID]; ok {
+		prev = *b
+	}
+	node := a.QSDID
+	volManager, uploader := c.volManager, c.uploader
+	c.mu.Unlock()
+
+	n, err := volManager.GetNode(ctx, node)
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed getting the node of the volume %s: %v", p.VolumeID, err)
+		return failedBackup(errMessage, err)
+	}
+	bitmap := hasBitmap(n, BackupBitmap)
+	incremental := p.Incremental && bitmap && prev.Last != "" && prev.Node == node
+	if p.Incremental && !incremental {
+		log.Infof("Incremental backup not possible for the volume %s, take a full backup", p.VolumeID)
+	}
+
+	dir := filepath.Join(backupsDir, p.VolumeID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create the backup directory for the volume %s: %v", p.VolumeID, err)
+		return failedBackup(errMessage, err)
+	}
+	target := filepath.Join(dir, fmt.Sprintf("%s.qcow2", p.BackupID))
+	if err := CreateImageFile(ctx, target, int64(n.Image.VirtualSize), ""); err != nil {
+		errMessage := fmt.Sprintf("Failed creating the backup image for %s: %v", p.VolumeID, err)
+		return failedBackup(errMessage, err)
+	}
+	defer os.Remove(target)
+	if err := volManager.Backup(ctx, node, target, incremental, !bitmap); err != nil {
+		errMessage := fmt.Sprintf("Failed backing up the volume %s: %v", p.VolumeID, err)
+		return failedBackup(errMessage, err)
+	}
+	// The bitmap has been reset by the backup, until the upload succeeds there
+	// is no valid parent for the next incremental backup
+	c.mu.Lock()
+	delete(c.backups, p.VolumeID)
+	c.mu.Unlock()
+
+	m := &backup.Manifest{
+		ID:          p.BackupID,
+		Volume:      p.VolumeID,
+		VirtualSize: int64(n.Image.VirtualSize),
+		Created:     time.Now().UTC(),
+	}
+	if incremental {
+		m.Parent = prev.Last
+	}
+	if err := uploader.Upload(ctx, backup.DataKey(m.Volume, m.ID), target); err != nil {
+		errMessage := fmt.Sprintf("Failed uploading the backup %s: %v", p.BackupID, err)
+		return failedBackup(errMessage, err)
+	}
+	// The manifest is uploaded last, a backup without manifest is incomplete
+	if err := backup.PutManifest(ctx, uploader, m); err != nil {
+		errMessage := fmt.Sprintf("Failed uploading the manifest of the backup %s: %v", p.BackupID, err)
+		return failedBackup(errMessage, err)
+	}
+	c.mu.Lock()
+	c.backups[p.VolumeID] = &backupState{Last: p.BackupID, Node: node}
+	c.mu.Unlock()
+	return &ResponseBackup{
+		Success:     true,
+		BackupID:    m.ID,
+		Parent:      m.Parent,
+		VirtualSize: m.VirtualSize,
+	}, nil
+}
+
+// RestoreVolume creates a new volume from a backup. The images of the backup chain
+// are downloaded, linked together and flattened in the image of the new volume.
+func (c *Server) RestoreVolume(ctx context.Context, p *RestoreParams) (*Response, error) {
+	log.Infof("Restore backup %s of %s in volume %s", p.BackupID, p.SourceVolumeID, p.VolumeID)
+	c.mu.Lock()
+	if _, ok := c.images[p.VolumeID]; ok {
+		c.mu.Unlock()
+		errMessage := fmt.Sprintf("Volume %s already exists", p.VolumeID)
+		return failed(errMessage, status.Error(codes.AlreadyExists, errMessage))
+	}
+	if err := c.startBackup(p.VolumeID); err != nil {
+		c.mu.Unlock()
+		return failed(err.Error(), err)
+	}
+	defer c.release(p.VolumeID)
+	uploader := c.uploader
+	c.mu.Unlock()
+
+	chain, err := backup.Chain(ctx, uploader, p.SourceVolumeID, p.BackupID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed getting the backup chain of %s: %v", p.BackupID, err)
+		return failed(errMessage, err)
+	}
+	dir := filepath.Join(backupsDir, fmt.Sprintf("restore-%s", p.VolumeID))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create the restore directory for the volume %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	defer os.RemoveAll(dir)
+	var top string
+	for _, m := range chain {
+		f := filepath.Join(dir, fmt.Sprintf("%s.qcow2", m.ID))
+		if err := uploader.Download(ctx, backup.DataKey(m.Volume, m.ID), f); err != nil {
+			errMessage := fmt.Sprintf("Failed downloading the backup %s: %v", m.ID, err)
+			return failed(errMessage, err)
+		}
+		// The clusters not copied by an incremental backup are read from its parent
+		if top != "" {
+			if err := RebaseImage(ctx, f, top); err != nil {
+				errMessage := fmt.Sprintf("Failed linking the backup %s: %v", m.ID, err)
+				return failed(errMessage, err)
+			}
+		}
+		top = f
+	}
+
+	volDir := fmt.Sprintf("%s/%s", imagesDir, p.VolumeID)
+	if err := os.MkdirAll(volDir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create directory for the volume:%s", p.VolumeID)
+		return failed(errMessage, err)
+	}
+	qcowImage := &QCOWImage{
+		File:      fmt.Sprintf("%s/%s", volDir, diskImg),
+		QSDID:     generateQSDID(p.VolumeID),
+		VolumeRef: p.VolumeID,
+		Created:   time.Now(),
+	}
+	if err := ConvertImage(ctx, top, qcowImage.File); err != nil {
+		errMessage := fmt.Sprintf("Failed restoring the backup %s: %v", p.BackupID, err)
+		return failed(errMessage, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.volManager.AddImage(ctx, qcowImage.File, qcowImage.QSDID, "", nil); err != nil {
+		errMessage := fmt.Sprintf("Failed adding the restored volume %s:%v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	c.images[p.VolumeID] = qcowImage
+	c.activeLayers[p.VolumeID] = p.VolumeID
+	return &Response{
+		Success: true,
+	}, nil
+}
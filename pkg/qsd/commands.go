@@ -1,6 +1,8 @@
 package qsd
 
 import (
+	"bufio"
+	context "context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,36 +14,34 @@ import (
 
 	"github.com/digitalocean/go-qemu/qmp"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
 )
 
 type VolumeManager struct {
 	Monitor *QMPMonitor
 }
 
-type communicationChan struct {
-	Monitor *QMPMonitor
-	err     error
-}
-
 func NewVolumeManager(socket string) (*VolumeManager, error) {
 	if socket == "" {
 		return nil, fmt.Errorf("The socket cannot be empty")
 	}
 	log.Infof("Create new NewVolumeManager for socket %s", socket)
-	c1 := make(chan communicationChan, 1)
-	go func() {
-		q, err := CreateNewUnixMonitor(socket)
-		c1 <- communicationChan{Monitor: q, err: err}
-	}()
-	select {
-	case res := <-c1:
-		if res.err != nil {
-			return nil, res.err
-		}
-		return &VolumeManager{Monitor: res.Monitor}, nil
-	case <-time.After(5 * time.Second):
-		return nil, fmt.Errorf("Timeout the communication with the qmp socket %s", socket)
+	q, err := CreateNewUnixMonitor(socket)
+	if err != nil {
+		return nil, err
 	}
+	return &VolumeManager{Monitor: q}, nil
+}
+
+// Reconnect reopens the connection with the monitor of a restarted daemon
+func (v *VolumeManager) Reconnect() error {
+	return v.Monitor.Reconnect()
+}
+
+// Ping executes a command without side effects to check that the daemon is responsive
+func (v *VolumeManager) Ping(ctx context.Context) error {
+	_, err := v.Monitor.ExecuteCommandRaw(ctx, `{ "execute": "query-version" }`)
+	return err
 }
 
 func (v *VolumeManager) Disconnect() {
@@ -60,57 +60,6 @@ type statusResult struct {
 	} `json:"return"`
 }
 
-type QMPMonitor struct {
-	monitor qmp.Monitor
-}
-
-func CreateNewUnixMonitor(socket string) (*QMPMonitor, error) {
-	m, err := qmp.NewSocketMonitor("unix", socket, 2*time.Second)
-	if err != nil {
-		return &QMPMonitor{}, fmt.Errorf("Fail in creating qmp connection: %v", err)
-	}
-	err = m.Connect()
-	if err != nil {
-		return &QMPMonitor{}, err
-	}
-	return &QMPMonitor{monitor: m}, nil
-}
-
-func (q *QMPMonitor) Disconnect() {
-	q.monitor.Disconnect()
-}
-
-func (q *QMPMonitor) ExecuteCommandRaw(qmpCmd string) ([]byte, error) {
-	cmd := []byte(qmpCmd)
-	fmt.Printf("Executed command %s\n", qmpCmd)
-	raw, err := q.monitor.Run(cmd)
-	if err != nil {
-		return raw, err
-	}
-	fmt.Printf("result: %s\n", string(raw))
-	return raw, nil
-
-}
-
-func (q *QMPMonitor) ExecuteCommand(qmpCmd string) error {
-	raw, err := q.ExecuteCommandRaw(qmpCmd)
-	if err != nil {
-		return err
-	}
-	var result statusResult
-	err = json.Unmarshal(raw, &result)
-	if err != nil {
-		return fmt.Errorf("failed parsing result %v", err)
-	}
-
-	fmt.Println(result.Return.Status)
-	return nil
-}
-
-func (q *QMPMonitor) Events() (<-chan qmp.Event, error) {
-	return q.monitor.Events()
-}
-
 const (
 	GB = 1024 * 1024 * 1024
 	MB = 1024 * 1024
@@ -143,32 +92,31 @@ func parseSizeToByteString(size string) (string, error) {
 	return "", fmt.Errorf("Quantity %s not supported", u)
 }
 
-func (v *VolumeManager) CreateNbdServer(exporter, path string) error {
+func (v *VolumeManager) CreateNbdServer(ctx context.Context, exporter, path string) error {
 	cmdCreateNbsServer := fmt.Sprintf(`{ 'execute': 'nbd-server-start','arguments': { 'addr': { 'type': 'unix','data': { 'path': '%s/nbd.sock' }}}}`, path)
 	cmdExportNbd := fmt.Sprintf(`{"execute":"nbd-server-add", "arguments":{"device":"imgfile", "name":"%s", "writable":true, "description":"%s exporter"}}`, exporter, exporter)
 
 	cmds := []string{cmdCreateNbsServer,
 		cmdExportNbd}
 	for _, c := range cmds {
-		if err := v.Monitor.ExecuteCommand(c); err != nil {
+		if err := v.Monitor.ExecuteCommand(ctx, c); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (v *VolumeManager) waitJobToComplete(id string) error {
-	chEvents, err := v.Monitor.Events()
-	if err != nil {
-		return fmt.Errorf("Failed creating monitor event %v", err)
-	}
+func (v *VolumeManager) waitJobToComplete(ctx context.Context, id string, chEvents <-chan qmp.Event) error {
+	timeout := time.After(time.Second * 10)
 	for {
 		select {
-		case <-time.After(time.Second * 10):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timeout:
 			return fmt.Errorf("Timeout in dismissing job %s", id)
 		case event := <-chEvents:
 			fmt.Printf("Events %v \n", event)
-			if event.Event == "BLOCK_JOB_COMPLETED" {
+			if event.Event == "BLOCK_JOB_COMPLETED" && event.Data["device"] == id {
 				fmt.Printf("Completed job %s \n", id)
 				return nil
 			}
@@ -176,60 +124,178 @@ func (v *VolumeManager) waitJobToComplete(id string) error {
 	}
 }
 
-func (v *VolumeManager) completeJob(id string) error {
+func (v *VolumeManager) completeJob(ctx context.Context, id string) error {
 	cmdJobDismiss := fmt.Sprintf(`{
   "execute": "job-complete",
   "arguments": {
     "id": "%s"
   }
 }`, id)
-	if err := v.Monitor.ExecuteCommand(cmdJobDismiss); err != nil {
+	// Subscribe before the command to not miss the completion of the job
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdJobDismiss); err != nil {
 		return err
 	}
-	return v.waitJobToComplete(id)
+	return v.waitJobToComplete(ctx, id, chEvents)
 }
 
-func (v *VolumeManager) dismissJob(id string) error {
+func (v *VolumeManager) dismissJob(ctx context.Context, id string) error {
 	cmdJobDismiss := fmt.Sprintf(`{
   "execute": "job-dismiss",
   "arguments": {
     "id": "%s"
   }
 }`, id)
-	if err := v.Monitor.ExecuteCommand(cmdJobDismiss); err != nil {
+	// Subscribe before the command to not miss the completion of the job
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdJobDismiss); err != nil {
 		return err
 	}
-	return v.waitJobToComplete(id)
+	return v.waitJobToComplete(ctx, id, chEvents)
+
+}
+
+// NodeOptions are applied to all the nodes of a volume
+type NodeOptions struct {
+	// Discard passes the discard requests to the image file
+	Discard      bool
+	CacheDirect  bool
+	CacheNoFlush bool
+	Aio          string
+	DetectZeroes string
+}
+
+// commonArgs returns the blockdev-add arguments for both the protocol and the format nodes
+func (o *NodeOptions) commonArgs() string {
+	var args string
+	if o.Discard {
+		args += `,
+    "discard": "unmap"`
+	}
+	if o.CacheDirect || o.CacheNoFlush {
+		args += fmt.Sprintf(`,
+    "cache": {"direct": %t, "no-flush": %t}`, o.CacheDirect, o.CacheNoFlush)
+	}
+	return args
+}
+
+func (o *NodeOptions) detectZeroesArg() string {
+	if o == nil || o.DetectZeroes == "" {
+		return ""
+	}
+	return fmt.Sprintf(`,
+    "detect-zeroes": "%s"`, o.DetectZeroes)
+}
 
+// protocolArgs returns the additional blockdev-add arguments for the file nodes
+func (o *NodeOptions) protocolArgs() string {
+	if o == nil {
+		return ""
+	}
+	args := o.commonArgs()
+	if o.Aio != "" {
+		args += fmt.Sprintf(`,
+    "aio": "%s"`, o.Aio)
+	}
+	return args
+}
+
+// formatArgs returns the additional blockdev-add arguments for the qcow2 nodes
+func (o *NodeOptions) formatArgs() string {
+	if o == nil {
+		return ""
+	}
+	return o.commonArgs() + o.detectZeroesArg()
 }
 
-func (v *VolumeManager) createImage(image, id, size, format string) error {
+// exportArgs returns the additional block-export-add arguments for the options
+func exportArgs(o *ExportOptions) string {
+	var args string
+	if o.GetNumQueues() > 0 {
+		args += fmt.Sprintf(`,
+    "num-queues": %d`, o.GetNumQueues())
+	}
+	if o.GetLogicalBlockSize() > 0 {
+		args += fmt.Sprintf(`,
+    "logical-block-size": %d`, o.GetLogicalBlockSize())
+	}
+	if o.GetWritethrough() {
+		args += `,
+    "writethrough": true`
+	}
+	return args
+}
+
+func (v *VolumeManager) createImage(ctx context.Context, image, id, size, format string, o *NodeOptions) error {
 	// if the image already exists do not recreate
 	if _, err := os.Stat(image); os.IsNotExist(err) {
-		cmd := exec.Command("qemu-img", "create", "-f", format, image, size)
+		cmd := exec.CommandContext(ctx, "qemu-img", "create", "-f", format, image, size)
 		stdoutStderr, err := cmd.CombinedOutput()
 		fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
 		if err != nil {
-			return fmt.Errorf("qemu-img failed err:%v", stdoutStderr, err)
+			return fmt.Errorf("qemu-img failed output: %s err:%v", stdoutStderr, err)
 		}
 	}
-	cmdBlockAddFile := fmt.Sprintf(`{
+	return v.addImageNodes(ctx, image, id, "", o)
+}
+
+// FormatNode returns the name of the qcow2 node of the image, the node used by the
+// exports, the jobs and the bitmaps
+func FormatNode(id string) string {
+	return fmt.Sprintf("qcow2-%s", id)
+}
+
+// ProtocolNode returns the name of the file node under the qcow2 node of the image
+func ProtocolNode(id string) string {
+	return fmt.Sprintf("file-%s", id)
+}
+
+// addImageNodes adds the file node of the image and the qcow2 node on top of it. An
+// empty backing adds the image without backing node.
+func (v *VolumeManager) addImageNodes(ctx context.Context, image, id, backing string, o *NodeOptions) error {
+	cmdAddFile := fmt.Sprintf(`{
   "execute": "blockdev-add",
   "arguments": {
     "driver": "file",
     "filename": "%s",
-    "node-name": "node-%s"
+    "node-name": "file-%s"%s
   }
-}`, image, id)
-
-	if err := v.Monitor.ExecuteCommand(cmdBlockAddFile); err != nil {
+}`, image, id, o.protocolArgs())
+	if err := v.Monitor.ExecuteCommand(ctx, cmdAddFile); err != nil {
+		return err
+	}
+	backingArg := "null"
+	if backing != "" {
+		backingArg = fmt.Sprintf(`"qcow2-%s"`, backing)
+	}
+	cmdAddFormat := fmt.Sprintf(`{
+  "execute": "blockdev-add",
+  "arguments": {
+    "driver": "qcow2",
+    "file": "file-%s",
+    "backing": %s,
+    "node-name": "qcow2-%s"%s
+  }
+}`, id, backingArg, id, o.formatArgs())
+	if err := v.Monitor.ExecuteCommand(ctx, cmdAddFormat); err != nil {
+		if err := v.deleteNode(context.Background(), ProtocolNode(id)); err != nil {
+			log.Errorf("Failed removing the file node of %s: %v", id, err)
+		}
 		return err
 	}
 	return nil
 }
 
-func (v *VolumeManager) CreateVolume(image, id, size string) error {
-	return v.createImage(image, id, size, "qcow2")
+// AddImage adds the nodes for an image file already present on the disk. Images
+// without a backing node are added as the base volumes, the others as overlays.
+func (v *VolumeManager) AddImage(ctx context.Context, image, id, backing string, o *NodeOptions) error {
+	return v.addImageNodes(ctx, image, id, backing, o)
+}
+
+func (v *VolumeManager) CreateVolume(ctx context.Context, image, id, size string, o *NodeOptions) error {
+	return v.createImage(ctx, image, id, size, "qcow2", o)
 }
 
 func isErrorBusyForBlockJob(err error) bool {
@@ -242,135 +308,222 @@ func isErrorBusyForBlockJob(err error) bool {
 	return false
 }
 
-func (v *VolumeManager) DeleteVolume(id string) error {
+// DeleteVolume removes the qcow2 node of the image and its file node
+func (v *VolumeManager) DeleteVolume(ctx context.Context, id string) error {
+	if err := v.deleteNode(ctx, FormatNode(id)); err != nil {
+		return err
+	}
+	return v.deleteNode(ctx, ProtocolNode(id))
+}
+
+func (v *VolumeManager) deleteNode(ctx context.Context, node string) error {
 	c := fmt.Sprintf(`{
   "execute": "blockdev-del",
   "arguments": {
-    "node-name": "node-%s"}}`, id)
-	return v.Monitor.ExecuteCommand(c)
+    "node-name": "%s"}}`, node)
+	return v.Monitor.ExecuteCommand(ctx, c)
 }
 
-func (v *VolumeManager) ExposeVhostUser(id, vhostSock string) error {
+// ExposeVhostUser exports the node with the vhost-user-blk export identified by id
+func (v *VolumeManager) ExposeVhostUser(ctx context.Context, id, node, vhostSock string, writable bool, o *ExportOptions) error {
 	c := fmt.Sprintf(`{
   "execute": "block-export-add",
   "arguments": {
     "id": "vhost-%s",
-    "node-name": "node-%s",
+    "node-name": "qcow2-%s",
     "type": "vhost-user-blk",
-    "writable": true,
+    "writable": %t,
     "addr": {
       "path": "%s",
       "type": "unix"
-    }
+    }%s
   }
-}`, id, id, vhostSock)
-	if err := v.Monitor.ExecuteCommand(c); err != nil {
+}`, id, node, writable, vhostSock, exportArgs(o))
+	if err := v.Monitor.ExecuteCommand(ctx, c); err != nil {
 		return err
 	}
 	return nil
 
 }
 
-func (v *VolumeManager) DeleteExporter(id string) error {
+func (v *VolumeManager) DeleteExporter(ctx context.Context, id string) error {
 	c := fmt.Sprintf(`{
   "execute": "block-export-del",
   "arguments": {
     "id": "vhost-%s"
   }
 }`, id)
-	if err := v.Monitor.ExecuteCommand(c); err != nil {
+	if err := v.Monitor.ExecuteCommand(ctx, c); err != nil {
 		return err
 	}
 	return nil
 
 }
 
-func (v *VolumeManager) CreateSnapshotWithBackingNode(imageID, snapshotID, image, snapshot, backing string) error {
+func (v *VolumeManager) CreateSnapshotWithBackingNode(ctx context.Context, imageID, snapshotID, image, snapshot, backing string, o *NodeOptions) error {
 
-	cmd := exec.Command("qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", image, snapshot)
+	cmd := exec.CommandContext(ctx, "qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", image, snapshot)
 	stdoutStderr, err := cmd.CombinedOutput()
 	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
 	if err != nil {
 		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
 	}
-	cmdBlockAdd := fmt.Sprintf(`{
-  "execute": "blockdev-add","arguments": {
-    "driver": "qcow2",
-    "file": {"driver": "file","filename": "%s"},
-    "backing": "node-%s",
-    "node-name": "node-%s"}}`, snapshot, backing, snapshotID)
-	return v.Monitor.ExecuteCommand(cmdBlockAdd)
+	return v.addImageNodes(ctx, snapshot, snapshotID, backing, o)
 }
 
-func (v *VolumeManager) CreateSnapshot(imageID, snapshotID, image, snapshot string) error {
-	cmd := exec.Command("qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", image, snapshot)
-	stdoutStderr, err := cmd.CombinedOutput()
-	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
-	if err != nil {
-		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
-	}
-	cmdBlockAdd := fmt.Sprintf(`{
-  "execute": "blockdev-add","arguments": {
-    "driver": "qcow2",
-    "file": {"driver": "file","filename": "%s"},
-    "backing": null,
-    "node-name": "node-%s"}}`, snapshot, snapshotID)
+// SnapshotRequest describes the overlay added on top of an image by a snapshot
+type SnapshotRequest struct {
+	ImageID    string
+	SnapshotID string
+	Image      string
+	Snapshot   string
+	// CarryBitmap moves the backup bitmap of the image on the overlay
+	CarryBitmap bool
+	Options     *NodeOptions
+}
 
-	cmdBlockSnap := fmt.Sprintf(`{
-  "execute": "blockdev-snapshot",
-  "arguments": {
-    "node": "node-%s",
-    "overlay": "node-%s"}}`, imageID, snapshotID)
-	cmds := []string{
-		cmdBlockAdd,
-		cmdBlockSnap,
+// snapshotActions returns the transaction actions to switch the image on the overlay
+func snapshotActions(r SnapshotRequest) []string {
+	actions := []string{fmt.Sprintf(`{"type": "blockdev-snapshot", "data": {"node": "qcow2-%s", "overlay": "qcow2-%s"}}`, r.ImageID, r.SnapshotID)}
+	if r.CarryBitmap {
+		actions = append(actions,
+			fmt.Sprintf(`{"type": "block-dirty-bitmap-add", "data": {"node": "qcow2-%s", "name": "%s", "persistent": true}}`, r.SnapshotID, BackupBitmap),
+			fmt.Sprintf(`{"type": "block-dirty-bitmap-merge", "data": {"node": "qcow2-%[1]s", "target": "%[3]s",
+        "bitmaps": [{"node": "qcow2-%[2]s", "name": "%[3]s"}]}}`, r.SnapshotID, r.ImageID, BackupBitmap))
 	}
+	return actions
+}
 
-	for _, c := range cmds {
-		if err := v.Monitor.ExecuteCommand(c); err != nil {
+// CreateSnapshot adds the overlay on top of the image. When carryBitmap is set, the
+// backup bitmap of the image is moved on the overlay in the same transaction of the
+// snapshot, so the writes since the last backup remain tracked on the active layer.
+func (v *VolumeManager) CreateSnapshot(ctx context.Context, imageID, snapshotID, image, snapshot string, carryBitmap bool, o *NodeOptions) error {
+	return v.CreateSnapshots(ctx, []SnapshotRequest{{
+		ImageID:     imageID,
+		SnapshotID:  snapshotID,
+		Image:       image,
+		Snapshot:    snapshot,
+		CarryBitmap: carryBitmap,
+		Options:     o,
+	}})
+}
+
+// CreateSnapshots adds the overlays on top of the images in a single transaction, the
+// snapshots are consistent between each other and are all taken or none.
+func (v *VolumeManager) CreateSnapshots(ctx context.Context, requests []SnapshotRequest) (err error) {
+	var added []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, id := range added {
+			if err := v.DeleteVolume(context.Background(), id); err != nil {
+				log.Errorf("Failed removing the nodes of the overlay %s: %v", id, err)
+			}
+		}
+		for _, r := range requests {
+			os.Remove(r.Snapshot)
+		}
+	}()
+	var actions []string
+	for _, r := range requests {
+		cmd := exec.CommandContext(ctx, "qemu-img", "create", "-f", "qcow2", "-F", "qcow2", "-b", r.Image, r.Snapshot)
+		stdoutStderr, err := cmd.CombinedOutput()
+		fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+		if err != nil {
+			return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+		}
+		// The overlay is added without backing, blockdev-snapshot attaches the image
+		if err := v.addImageNodes(ctx, r.Snapshot, r.SnapshotID, "", r.Options); err != nil {
 			return err
 		}
+		added = append(added, r.SnapshotID)
+		actions = append(actions, snapshotActions(r)...)
 	}
-	return nil
+	cmdTransaction := fmt.Sprintf(`{
+  "execute": "transaction",
+  "arguments": {
+    "actions": [
+      %s
+    ]
+  }
+}`, strings.Join(actions, ",\n      "))
+	return v.Monitor.ExecuteCommand(ctx, cmdTransaction)
 }
 
-func (v *VolumeManager) StreamImage(base, overlay string) error {
-	jobID := "job0"
+// CreateInternalSnapshot stores a snapshot named name in the qcow2 image of the node,
+// the snapshot shares the clusters with the image and doesn't add a layer to the chain
+func (v *VolumeManager) CreateInternalSnapshot(ctx context.Context, id, name string) error {
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-snapshot-internal-sync",
+  "arguments": {
+    "device": "qcow2-%s",
+    "name": "%s"
+  }
+}`, id, name)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// DeleteInternalSnapshot removes the snapshot named name from the qcow2 image of the node
+func (v *VolumeManager) DeleteInternalSnapshot(ctx context.Context, id, name string) error {
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-snapshot-delete-internal-sync",
+  "arguments": {
+    "device": "qcow2-%s",
+    "name": "%s"
+  }
+}`, id, name)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// StreamImage copies in the overlay the data of the images between the overlay and
+// base, and removes them from its backing chain
+func (v *VolumeManager) StreamImage(ctx context.Context, base, overlay string) error {
+	jobID := fmt.Sprintf("stream-%s", overlay)
 	cmdBlockstream := fmt.Sprintf(`{
     "execute": "block-stream",
     "arguments": {
-        "device": "node-%s",
+        "device": "qcow2-%s",
         "job-id": "%s",
-	"base-node": "node-%s"}}`, overlay, jobID, base)
-	//	if err := v.Monitor.ExecuteCommand(cmdBlockstream); err != nil {
-	//		return err
-	//	}
-	//	return v.dismissJob(jobID)
-	return v.Monitor.ExecuteCommand(cmdBlockstream)
+	"base-node": "qcow2-%s"}}`, overlay, jobID, base)
+	// Subscribe before starting the job to not miss its completion
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdBlockstream); err != nil {
+		return err
+	}
+	if err := waitBlockJob(ctx, chEvents, jobID); err != nil {
+		if ctx.Err() != nil {
+			v.CancelJob(context.Background(), jobID)
+		}
+		return err
+	}
+	return nil
 }
 
-func (v *VolumeManager) CommitImage(node, top, base string) error {
+func (v *VolumeManager) CommitImage(ctx context.Context, node, top, base string) error {
 	jobID := "job0"
 	cmdBlockCommit := fmt.Sprintf(`{
     "execute": "block-commit",
     "arguments": {
-        "device": "node-%s",
+        "device": "qcow2-%s",
         "job-id": "%s",
 	"top": "%s",
         "base": "%s"}}`, node, jobID, top, base)
 
-	err := v.Monitor.ExecuteCommand(cmdBlockCommit)
+	err := v.Monitor.ExecuteCommand(ctx, cmdBlockCommit)
 	if err != nil {
 		return nil
 	}
 
-	return v.completeJob(jobID)
+	return v.completeJob(ctx, jobID)
 }
 
 type ImageInfo struct {
 	Filename              string           `json:"filename"`
 	Format                string           `json:"format"`
 	VirtualSize           int              `json:"virtual-size"`
+	ActualSize            int64            `json:"actual-size"`
 	BackingFile           string           `json:"backing_file"`
 	FullBackingFilename   string           `json:"full-backing-filename"`
 	BackingFilenameFormat string           `json:"backing-filename-format"`
@@ -416,6 +569,15 @@ type NameBlockNode struct {
 	IopsSize         int       `json:"iops_size"`
 	WriteThreshold   int       `json:"write_threshold"`
 	Image            ImageInfo `json:"image"`
+	DirtyBitmaps     []Bitmap  `json:"dirty-bitmaps"`
+}
+
+type Bitmap struct {
+	Name        string `json:"name"`
+	Count       int64  `json:"count"`
+	Granularity int64  `json:"granularity"`
+	Recording   bool   `json:"recording"`
+	Persistent  bool   `json:"persistent"`
 }
 
 type QueryNameBlockNodesReturn struct {
@@ -423,9 +585,9 @@ type QueryNameBlockNodesReturn struct {
 	Return []NameBlockNode `json:"return"`
 }
 
-func (v *VolumeManager) GetNameBlockNodes() ([]NameBlockNode, error) {
+func (v *VolumeManager) GetNameBlockNodes(ctx context.Context) ([]NameBlockNode, error) {
 	cmdQueryNamedBlockNodes := `{ "execute": "query-named-block-nodes" }`
-	raw, err := v.Monitor.ExecuteCommandRaw(cmdQueryNamedBlockNodes)
+	raw, err := v.Monitor.ExecuteCommandRaw(ctx, cmdQueryNamedBlockNodes)
 	if err != nil {
 		return []NameBlockNode{}, err
 	}
@@ -437,3 +599,780 @@ func (v *VolumeManager) GetNameBlockNodes() ([]NameBlockNode, error) {
 
 	return result.Return, nil
 }
+
+type LatencyHistogram struct {
+	Boundaries []uint64 `json:"boundaries"`
+	Bins       []uint64 `json:"bins"`
+}
+
+type BlockDeviceTimedStats struct {
+	IntervalLength  int64   `json:"interval_length"`
+	MinRdLatencyNs  int64   `json:"min_rd_latency_ns"`
+	MaxRdLatencyNs  int64   `json:"max_rd_latency_ns"`
+	AvgRdLatencyNs  int64   `json:"avg_rd_latency_ns"`
+	MinWrLatencyNs  int64   `json:"min_wr_latency_ns"`
+	MaxWrLatencyNs  int64   `json:"max_wr_latency_ns"`
+	AvgWrLatencyNs  int64   `json:"avg_wr_latency_ns"`
+	AvgRdQueueDepth float64 `json:"avg_rd_queue_depth"`
+	AvgWrQueueDepth float64 `json:"avg_wr_queue_depth"`
+}
+
+// BlockDeviceStats are the I/O statistics of a block node, the qcow2 node of the
+// active layer receives the requests of all the exports of the volume
+type BlockDeviceStats struct {
+	RdBytes            uint64                  `json:"rd_bytes"`
+	WrBytes            uint64                  `json:"wr_bytes"`
+	RdOperations       uint64                  `json:"rd_operations"`
+	WrOperations       uint64                  `json:"wr_operations"`
+	FlushOperations    uint64                  `json:"flush_operations"`
+	RdTotalTimeNs      uint64                  `json:"rd_total_time_ns"`
+	WrTotalTimeNs      uint64                  `json:"wr_total_time_ns"`
+	FlushTotalTimeNs   uint64                  `json:"flush_total_time_ns"`
+	FailedRdOperations uint64                  `json:"failed_rd_operations"`
+	FailedWrOperations uint64                  `json:"failed_wr_operations"`
+	WrHighestOffset    uint64                  `json:"wr_highest_offset"`
+	TimedStats         []BlockDeviceTimedStats `json:"timed_stats"`
+	RdLatencyHistogram *LatencyHistogram       `json:"rd_latency_histogram"`
+	WrLatencyHistogram *LatencyHistogram       `json:"wr_latency_histogram"`
+}
+
+type BlockStats struct {
+	Device   string           `json:"device"`
+	NodeName string           `json:"node-name"`
+	Stats    BlockDeviceStats `json:"stats"`
+}
+
+type QueryBlockStatsReturn struct {
+	ID     string       `json:"id"`
+	Return []BlockStats `json:"return"`
+}
+
+// GetBlockStats returns the I/O statistics for all the named nodes
+func (v *VolumeManager) GetBlockStats(ctx context.Context) ([]BlockStats, error) {
+	cmdQueryBlockStats := `{ "execute": "query-blockstats", "arguments": { "query-nodes": true } }`
+	raw, err := v.Monitor.ExecuteCommandRaw(ctx, cmdQueryBlockStats)
+	if err != nil {
+		return []BlockStats{}, err
+	}
+	var result QueryBlockStatsReturn
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return []BlockStats{}, fmt.Errorf("failed parsing result %v", err)
+	}
+
+	return result.Return, nil
+}
+
+type BlockExportInfo struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	NodeName     string `json:"node-name"`
+	ShuttingDown bool   `json:"shutting-down"`
+}
+
+type QueryBlockExportsReturn struct {
+	ID     string            `json:"id"`
+	Return []BlockExportInfo `json:"return"`
+}
+
+func (v *VolumeManager) GetBlockExports(ctx context.Context) ([]BlockExportInfo, error) {
+	cmdQueryBlockExports := `{ "execute": "query-block-exports" }`
+	raw, err := v.Monitor.ExecuteCommandRaw(ctx, cmdQueryBlockExports)
+	if err != nil {
+		return []BlockExportInfo{}, err
+	}
+	var result QueryBlockExportsReturn
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return []BlockExportInfo{}, fmt.Errorf("failed parsing result %v", err)
+	}
+
+	return result.Return, nil
+}
+
+type JobInfo struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	Status          string `json:"status"`
+	CurrentProgress int64  `json:"current-progress"`
+	TotalProgress   int64  `json:"total-progress"`
+	Error           string `json:"error"`
+}
+
+type QueryJobsReturn struct {
+	ID     string    `json:"id"`
+	Return []JobInfo `json:"return"`
+}
+
+func (v *VolumeManager) GetJobs(ctx context.Context) ([]JobInfo, error) {
+	cmdQueryJobs := `{ "execute": "query-jobs" }`
+	raw, err := v.Monitor.ExecuteCommandRaw(ctx, cmdQueryJobs)
+	if err != nil {
+		return []JobInfo{}, err
+	}
+	var result QueryJobsReturn
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return []JobInfo{}, fmt.Errorf("failed parsing result %v", err)
+	}
+
+	return result.Return, nil
+}
+
+// CancelJob cancels a block job, mirror jobs in ready state complete without pivoting
+func (v *VolumeManager) CancelJob(ctx context.Context, id string) error {
+	c := fmt.Sprintf(`{
+  "execute": "job-cancel",
+  "arguments": {
+    "id": "%s"
+  }
+}`, id)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// ForceDeleteExporter removes the export even if clients are still connected
+func (v *VolumeManager) ForceDeleteExporter(ctx context.Context, id string) error {
+	return v.forceDeleteExport(ctx, fmt.Sprintf("vhost-%s", id))
+}
+
+// ForceDeleteNBDExport removes the NBD export even if clients are still connected
+func (v *VolumeManager) ForceDeleteNBDExport(ctx context.Context, id string) error {
+	return v.forceDeleteExport(ctx, fmt.Sprintf("nbd-%s", id))
+}
+
+func (v *VolumeManager) forceDeleteExport(ctx context.Context, exportID string) error {
+	c := fmt.Sprintf(`{
+  "execute": "block-export-del",
+  "arguments": {
+    "id": "%s",
+    "mode": "hard"
+  }
+}`, exportID)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// Quit terminates the qemu-storage-daemon
+func (v *VolumeManager) Quit(ctx context.Context) error {
+	return v.Monitor.ExecuteCommand(ctx, `{ "execute": "quit" }`)
+}
+
+// BackupBitmap is the dirty bitmap tracking the writes since the last backup
+const BackupBitmap = "backup"
+
+// GetNode returns the named node
+func (v *VolumeManager) GetNode(ctx context.Context, id string) (*NameBlockNode, error) {
+	nodes, err := v.GetNameBlockNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.NodeName == fmt.Sprintf("qcow2-%s", id) {
+			return &n, nil
+		}
+	}
+	return nil, fmt.Errorf("Node %s not found", FormatNode(id))
+}
+
+// AddBitmap starts tracking the writes on the node. Persistent bitmaps are stored
+// in the qcow2 image and survive the restarts of the daemon.
+func (v *VolumeManager) AddBitmap(ctx context.Context, id, name string, persistent bool) error {
+	c := fmt.Sprintf(`{
+  "execute": "block-dirty-bitmap-add",
+  "arguments": {
+    "node": "qcow2-%s",
+    "name": "%s",
+    "persistent": %t
+  }
+}`, id, name, persistent)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// waitBlockJob waits for the termination of the block job and returns its error
+func waitBlockJob(ctx context.Context, chEvents <-chan qmp.Event, jobID string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event := <-chEvents:
+			if event.Data["device"] != jobID {
+				continue
+			}
+			switch event.Event {
+			case "BLOCK_JOB_COMPLETED":
+				if e, ok := event.Data["error"]; ok {
+					return fmt.Errorf("Job %s failed: %v", jobID, e)
+				}
+				return nil
+			case "BLOCK_JOB_CANCELLED":
+				return fmt.Errorf("Job %s cancelled", jobID)
+			}
+		}
+	}
+}
+
+// Backup copies the node in the qcow2 target image with a blockdev-backup job. A full
+// backup resets the bitmap in the same transaction, so the bitmap tracks exactly the
+// writes after the point in time of the backup. An incremental backup copies only the
+// clusters marked in the bitmap and clears it when the job succeeds. The bitmap is
+// stored in the qcow2 image and survives the restarts of the daemon.
+func (v *VolumeManager) Backup(ctx context.Context, id, target string, incremental, addBitmap bool) error {
+	jobID := fmt.Sprintf("backup-%s", id)
+	targetNode := fmt.Sprintf("target-%s", id)
+	cmdAddTarget := fmt.Sprintf(`{
+  "execute": "blockdev-add","arguments": {
+    "driver": "qcow2",
+    "file": {"driver": "file","filename": "%s"},
+    "node-name": "%s"}}`, target, targetNode)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdAddTarget); err != nil {
+		return err
+	}
+	defer func() {
+		cmdDelTarget := fmt.Sprintf(`{ "execute": "blockdev-del", "arguments": { "node-name": "%s" }}`, targetNode)
+		if err := v.Monitor.ExecuteCommand(context.Background(), cmdDelTarget); err != nil {
+			log.Errorf("Failed removing the backup target %s: %v", targetNode, err)
+		}
+	}()
+
+	var cmdBackup string
+	if incremental {
+		cmdBackup = fmt.Sprintf(`{
+  "execute": "blockdev-backup",
+  "arguments": {
+    "device": "qcow2-%s",
+    "target": "%s",
+    "job-id": "%s",
+    "sync": "bitmap",
+    "bitmap": "%s",
+    "bitmap-mode": "on-success"
+  }
+}`, id, targetNode, jobID, BackupBitmap)
+	} else {
+		bitmapAction := "block-dirty-bitmap-clear"
+		bitmapArgs := fmt.Sprintf(`"node": "qcow2-%s", "name": "%s"`, id, BackupBitmap)
+		if addBitmap {
+			bitmapAction = "block-dirty-bitmap-add"
+			bitmapArgs = fmt.Sprintf(`%s, "persistent": true`, bitmapArgs)
+		}
+		cmdBackup = fmt.Sprintf(`{
+  "execute": "transaction",
+  "arguments": {
+    "actions": [
+      {"type": "%s", "data": {%s}},
+      {"type": "blockdev-backup", "data": {"device": "qcow2-%s", "target": "%s", "job-id": "%s", "sync": "full"}}
+    ]
+  }
+}`, bitmapAction, bitmapArgs, id, targetNode, jobID)
+	}
+	// Subscribe before starting the job to not miss its completion
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdBackup); err != nil {
+		return err
+	}
+	if err := waitBlockJob(ctx, chEvents, jobID); err != nil {
+		if ctx.Err() != nil {
+			v.CancelJob(context.Background(), jobID)
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateImageFile creates an empty qcow2 image, optionally with a backing file
+func CreateImageFile(ctx context.Context, image string, size int64, backing string) error {
+	args := []string{"create", "-f", "qcow2"}
+	if backing != "" {
+		args = append(args, "-F", "qcow2", "-b", backing)
+	}
+	args = append(args, image, strconv.FormatInt(size, 10))
+	cmd := exec.CommandContext(ctx, "qemu-img", args...)
+	stdoutStderr, err := cmd.CombinedOutput()
+	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+	if err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+	}
+	return nil
+}
+
+// RebaseImage changes the backing file in the header of the image without copying the data
+func RebaseImage(ctx context.Context, image, backing string) error {
+	cmd := exec.CommandContext(ctx, "qemu-img", "rebase", "-u", "-f", "qcow2", "-F", "qcow2", "-b", backing, image)
+	stdoutStderr, err := cmd.CombinedOutput()
+	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+	if err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+	}
+	return nil
+}
+
+// ConvertImage flattens the backing chain of src in the standalone qcow2 image dst
+func ConvertImage(ctx context.Context, src, dst string) error {
+	cmd := exec.CommandContext(ctx, "qemu-img", "convert", "-O", "qcow2", src, dst)
+	stdoutStderr, err := cmd.CombinedOutput()
+	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+	if err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+	}
+	return nil
+}
+
+// ConvertSnapshot copies the internal snapshot name of the image src in the
+// standalone qcow2 image dst. The image is in use by the daemon, the data of the
+// snapshot doesn't change.
+func ConvertSnapshot(ctx context.Context, src, name, dst string) error {
+	cmd := exec.CommandContext(ctx, "qemu-img", "convert", "-U", "-f", "qcow2", "-l", fmt.Sprintf("snapshot.name=%s", name), "-O", "qcow2", src, dst)
+	stdoutStderr, err := cmd.CombinedOutput()
+	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+	if err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+	}
+	return nil
+}
+
+const (
+	nbdServerTLS = "tls-nbd-server"
+	nbdClientTLS = "tls-nbd-client"
+)
+
+// addTLSCreds creates the x509 credentials for the NBD connections from the
+// certificates in dir. The credentials are created only once.
+func (v *VolumeManager) addTLSCreds(ctx context.Context, id, endpoint, dir string) error {
+	c := fmt.Sprintf(`{
+  "execute": "object-add",
+  "arguments": {
+    "qom-type": "tls-creds-x509",
+    "id": "%s",
+    "dir": "%s",
+    "endpoint": "%s",
+    "verify-peer": true
+  }
+}`, id, dir, endpoint)
+	err := v.Monitor.ExecuteCommand(ctx, c)
+	if err != nil && strings.Contains(err.Error(), "duplicate") {
+		return nil
+	}
+	return err
+}
+
+// StartNBDServer listens for the NBD clients on the TCP port, with TLS if tlsDir
+// is set. Starting an already running server is not an error.
+func (v *VolumeManager) StartNBDServer(ctx context.Context, port, tlsDir string) error {
+	tls := ""
+	if tlsDir != "" {
+		if err := v.addTLSCreds(ctx, nbdServerTLS, "server", tlsDir); err != nil {
+			return err
+		}
+		tls = fmt.Sprintf(`, "tls-creds": "%s"`, nbdServerTLS)
+	}
+	c := fmt.Sprintf(`{
+  "execute": "nbd-server-start",
+  "arguments": {
+    "addr": {"type": "inet", "data": {"host": "0.0.0.0", "port": "%s"}}%s
+  }
+}`, port, tls)
+	err := v.Monitor.ExecuteCommand(ctx, c)
+	if err != nil && strings.Contains(err.Error(), "already running") {
+		return nil
+	}
+	return err
+}
+
+// ExposeNBD exports the node read-only on the NBD server with the name id
+func (v *VolumeManager) ExposeNBD(ctx context.Context, id, node string) error {
+	return v.exposeNBD(ctx, id, node, false)
+}
+
+// ExposeNBDWritable exports the node on the NBD server with the name id for the
+// writes of a remote mirror
+func (v *VolumeManager) ExposeNBDWritable(ctx context.Context, id, node string) error {
+	return v.exposeNBD(ctx, id, node, true)
+}
+
+func (v *VolumeManager) exposeNBD(ctx context.Context, id, node string, writable bool) error {
+	c := fmt.Sprintf(`{
+  "execute": "block-export-add",
+  "arguments": {
+    "id": "nbd-%s",
+    "node-name": "qcow2-%s",
+    "type": "nbd",
+    "name": "%s",
+    "writable": %t
+  }
+}`, id, node, id, writable)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+func (v *VolumeManager) DeleteNBDExport(ctx context.Context, id string) error {
+	c := fmt.Sprintf(`{
+  "execute": "block-export-del",
+  "arguments": {
+    "id": "nbd-%s"
+  }
+}`, id)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// AddNBDNode connects the node nbd-<id> to the export of a remote NBD server
+func (v *VolumeManager) AddNBDNode(ctx context.Context, id, host, port, export, tlsDir string) error {
+	return v.addNBDNode(ctx, fmt.Sprintf("nbd-%s", id), host, port, export, tlsDir, true)
+}
+
+func (v *VolumeManager) addNBDNode(ctx context.Context, node, host, port, export, tlsDir string, readOnly bool) error {
+	tls := ""
+	if tlsDir != "" {
+		if err := v.addTLSCreds(ctx, nbdClientTLS, "client", tlsDir); err != nil {
+			return err
+		}
+		tls = fmt.Sprintf(`, "tls-creds": "%s"`, nbdClientTLS)
+	}
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-add",
+  "arguments": {
+    "driver": "nbd",
+    "node-name": "%s",
+    "server": {"type": "inet", "host": "%s", "port": "%s"},
+    "export": "%s",
+    "read-only": %t%s
+  }
+}`, node, host, port, export, readOnly, tls)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+func (v *VolumeManager) DeleteNBDNode(ctx context.Context, id string) error {
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-del",
+  "arguments": {
+    "node-name": "nbd-%s"}}`, id)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// replicaJob is the id of the mirror job replicating the volume with the node id
+func replicaJob(id string) string {
+	return fmt.Sprintf("replica-%s", id)
+}
+
+// AddReplicaNode connects the node replica-<id> to the writable export of the replica
+func (v *VolumeManager) AddReplicaNode(ctx context.Context, id, host, port, export, tlsDir string) error {
+	return v.addNBDNode(ctx, fmt.Sprintf("replica-%s", id), host, port, export, tlsDir, false)
+}
+
+func (v *VolumeManager) DeleteReplicaNode(ctx context.Context, id string) error {
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-del",
+  "arguments": {
+    "node-name": "replica-%s"}}`, id)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// StartReplication mirrors the node on the replica node of the volume id. With the
+// write-blocking mode a write completes only once written on the replica too. The
+// job never completes, it is cancelled to stop the replication.
+func (v *VolumeManager) StartReplication(ctx context.Context, id, node string) error {
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-mirror",
+  "arguments": {
+    "job-id": "%s",
+    "device": "qcow2-%s",
+    "target": "replica-%s",
+    "sync": "full",
+    "copy-mode": "write-blocking"
+  }
+}`, replicaJob(id), node, id)
+	return v.Monitor.ExecuteCommand(ctx, c)
+}
+
+// StopReplication cancels the mirror job of the volume id and waits for its termination
+func (v *VolumeManager) StopReplication(ctx context.Context, id string) error {
+	jobID := replicaJob(id)
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	// The job is already gone if the replica failed
+	if err := v.CancelJob(ctx, jobID); err != nil {
+		log.Warningf("Failed cancelling the job %s: %v", jobID, err)
+		return nil
+	}
+	// The ready job completes and the others are cancelled
+	if err := waitBlockJob(ctx, chEvents, jobID); err != nil && ctx.Err() != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplicationBitmap tracks the writes since the last sync of an asynchronous replica,
+// replicationFrozenBitmap holds the writes copied by the sync in progress
+const (
+	ReplicationBitmap       = "replication"
+	replicationFrozenBitmap = "replication-frozen"
+)
+
+// SyncReplica copies the node on the replica node of the volume id with a
+// blockdev-backup job. A full sync resets the bitmap in the same transaction. An
+// incremental sync moves the bitmap in a frozen bitmap copied by the job, while the
+// emptied bitmap tracks the new writes. If the job fails, the frozen bitmap is merged
+// back so the next sync copies those clusters again.
+func (v *VolumeManager) SyncReplica(ctx context.Context, id, node string, incremental, addBitmap bool) error {
+	jobID := replicaJob(id)
+	var actions string
+	if incremental {
+		actions = fmt.Sprintf(`
+      {"type": "block-dirty-bitmap-add", "data": {"node": "qcow2-%[1]s", "name": "%[3]s", "disabled": true}},
+      {"type": "block-dirty-bitmap-merge", "data": {"node": "qcow2-%[1]s", "target": "%[3]s", "bitmaps": ["%[2]s"]}},
+      {"type": "block-dirty-bitmap-clear", "data": {"node": "qcow2-%[1]s", "name": "%[2]s"}},
+      {"type": "blockdev-backup", "data": {"device": "qcow2-%[1]s", "target": "replica-%[4]s", "job-id": "%[5]s", "sync": "incremental", "bitmap": "%[3]s"}}`,
+			node, ReplicationBitmap, replicationFrozenBitmap, id, jobID)
+	} else {
+		bitmapAction := "block-dirty-bitmap-clear"
+		bitmapArgs := fmt.Sprintf(`"node": "qcow2-%s", "name": "%s"`, node, ReplicationBitmap)
+		if addBitmap {
+			bitmapAction = "block-dirty-bitmap-add"
+			bitmapArgs = fmt.Sprintf(`%s, "persistent": true`, bitmapArgs)
+		}
+		actions = fmt.Sprintf(`
+      {"type": "%s", "data": {%s}},
+      {"type": "blockdev-backup", "data": {"device": "qcow2-%s", "target": "replica-%s", "job-id": "%s", "sync": "full"}}`,
+			bitmapAction, bitmapArgs, node, id, jobID)
+	}
+	cmdSync := fmt.Sprintf(`{
+  "execute": "transaction",
+  "arguments": {
+    "actions": [%s
+    ]
+  }
+}`, actions)
+	// Subscribe before starting the job to not miss its completion
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdSync); err != nil {
+		return err
+	}
+	err := waitBlockJob(ctx, chEvents, jobID)
+	if err != nil && ctx.Err() != nil {
+		v.CancelJob(context.Background(), jobID)
+	}
+	if !incremental {
+		return err
+	}
+	if err != nil {
+		cmdMerge := fmt.Sprintf(`{
+  "execute": "block-dirty-bitmap-merge",
+  "arguments": {"node": "qcow2-%s", "target": "%s", "bitmaps": ["%s"]}}`, node, ReplicationBitmap, replicationFrozenBitmap)
+		if err := v.Monitor.ExecuteCommand(context.Background(), cmdMerge); err != nil {
+			log.Errorf("Failed restoring the bitmap of the replica %s: %v", id, err)
+		}
+	}
+	cmdRemove := fmt.Sprintf(`{
+  "execute": "block-dirty-bitmap-remove",
+  "arguments": {"node": "qcow2-%s", "name": "%s"}}`, node, replicationFrozenBitmap)
+	if err := v.Monitor.ExecuteCommand(context.Background(), cmdRemove); err != nil {
+		log.Errorf("Failed removing the frozen bitmap of the replica %s: %v", id, err)
+	}
+	return err
+}
+
+// waitJobReady waits until the mirror job has copied all the data
+func waitJobReady(ctx context.Context, chEvents <-chan qmp.Event, jobID string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event := <-chEvents:
+			if event.Data["device"] != jobID {
+				continue
+			}
+			switch event.Event {
+			case "BLOCK_JOB_READY":
+				return nil
+			case "BLOCK_JOB_COMPLETED":
+				if e, ok := event.Data["error"]; ok {
+					return fmt.Errorf("Job %s failed: %v", jobID, e)
+				}
+				return fmt.Errorf("Job %s completed before being ready", jobID)
+			case "BLOCK_JOB_CANCELLED":
+				return fmt.Errorf("Job %s cancelled", jobID)
+			}
+		}
+	}
+}
+
+// MirrorFromNBD copies the remote export connected at nbd-<id> in the node of the
+// volume id. The mirror job is completed once the copy converged.
+func (v *VolumeManager) MirrorFromNBD(ctx context.Context, id string) error {
+	jobID := fmt.Sprintf("mirror-%s", id)
+	c := fmt.Sprintf(`{
+  "execute": "blockdev-mirror",
+  "arguments": {
+    "job-id": "%s",
+    "device": "nbd-%s",
+    "target": "qcow2-%s",
+    "sync": "full"
+  }
+}`, jobID, id, id)
+	// Subscribe before starting the job to not miss its events
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, c); err != nil {
+		return err
+	}
+	err := waitJobReady(ctx, chEvents, jobID)
+	if err == nil {
+		cmdComplete := fmt.Sprintf(`{ "execute": "job-complete", "arguments": { "id": "%s" }}`, jobID)
+		if err = v.Monitor.ExecuteCommand(ctx, cmdComplete); err == nil {
+			err = waitBlockJob(ctx, chEvents, jobID)
+		}
+	}
+	if err != nil && ctx.Err() != nil {
+		v.CancelJob(context.Background(), jobID)
+	}
+	return err
+}
+
+var progressRegexp = regexp.MustCompile(`\((\d+\.\d+)/100%\)`)
+
+// scanProgress splits the output of qemu-img -p, the progress is rewritten on the
+// same line with a carriage return
+func scanProgress(data []byte, atEOF bool) (int, []byte, error) {
+	for i, b := range data {
+		if b == '\r' || b == '\n' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// ImportImage converts the image src, a local path or a http(s) URL, in the qcow2
+// image dst. The format of src is detected by qemu-img if empty.
+func ImportImage(ctx context.Context, src, format, dst string, progress func(float32)) error {
+	args := []string{"convert", "-p"}
+	if format != "" {
+		args = append(args, "-f", format)
+	}
+	args = append(args, "-O", "qcow2", src, dst)
+	cmd := exec.CommandContext(ctx, "qemu-img", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(scanProgress)
+	for scanner.Scan() {
+		m := progressRegexp.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		if p, err := strconv.ParseFloat(m[1], 32); err == nil {
+			progress(float32(p))
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stderr.String(), err)
+	}
+	return nil
+}
+
+// ExportNode copies the node in the image target of the given format with a
+// blockdev-backup job, the node can be in use during the copy
+func (v *VolumeManager) ExportNode(ctx context.Context, id, target, format string, progress func(float32)) error {
+	jobID := fmt.Sprintf("export-%s", id)
+	targetNode := fmt.Sprintf("export-%s", id)
+	cmdAddTarget := fmt.Sprintf(`{
+  "execute": "blockdev-add","arguments": {
+    "driver": "%s",
+    "file": {"driver": "file","filename": "%s"},
+    "node-name": "%s"}}`, format, target, targetNode)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdAddTarget); err != nil {
+		return err
+	}
+	defer func() {
+		cmdDelTarget := fmt.Sprintf(`{ "execute": "blockdev-del", "arguments": { "node-name": "%s" }}`, targetNode)
+		if err := v.Monitor.ExecuteCommand(context.Background(), cmdDelTarget); err != nil {
+			log.Errorf("Failed removing the export target %s: %v", targetNode, err)
+		}
+	}()
+	cmdBackup := fmt.Sprintf(`{
+  "execute": "blockdev-backup",
+  "arguments": {
+    "device": "qcow2-%s",
+    "target": "%s",
+    "job-id": "%s",
+    "sync": "full"
+  }
+}`, id, targetNode, jobID)
+	// Subscribe before starting the job to not miss its completion
+	chEvents := v.Monitor.Subscribe()
+	defer v.Monitor.Unsubscribe(chEvents)
+	if err := v.Monitor.ExecuteCommand(ctx, cmdBackup); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- waitBlockJob(ctx, chEvents, jobID)
+	}()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err != nil && ctx.Err() != nil {
+				v.CancelJob(context.Background(), jobID)
+			}
+			return err
+		case <-ticker.C:
+			jobs, err := v.GetJobs(ctx)
+			if err != nil {
+				continue
+			}
+			for _, j := range jobs {
+				if j.ID == jobID && j.TotalProgress > 0 {
+					progress(float32(j.CurrentProgress) * 100 / float32(j.TotalProgress))
+				}
+			}
+		}
+	}
+}
+
+// CreateImageFormat creates an empty image of the format
+func CreateImageFormat(ctx context.Context, image, format string, size int64) error {
+	cmd := exec.CommandContext(ctx, "qemu-img", "create", "-f", format, image, strconv.FormatInt(size, 10))
+	stdoutStderr, err := cmd.CombinedOutput()
+	fmt.Printf("execute: qemu-img output: %s \n", stdoutStderr)
+	if err != nil {
+		return fmt.Errorf("%v failed output: %s err:%v", cmd, stdoutStderr, err)
+	}
+	return nil
+}
+
+// MapEntry is an extent of the image reported by qemu-img map
+type MapEntry struct {
+	Start  int64 `json:"start"`
+	Length int64 `json:"length"`
+	Depth  int   `json:"depth"`
+	// Present is set when the extent is allocated in the image or in one of its backing images
+	Present bool `json:"present"`
+	Zero    bool `json:"zero"`
+	Data    bool `json:"data"`
+}
+
+// MapImage returns the extents of the image and where they are allocated in the
+// backing chain. The image can be in use by the daemon.
+func MapImage(ctx context.Context, image string) ([]MapEntry, error) {
+	cmd := exec.CommandContext(ctx, "qemu-img", "map", "--output=json", "-U", "-f", "qcow2", image)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v failed output: %s err:%v", cmd, stderr.String(), err)
+	}
+	var entries []MapEntry
+	if err := json.Unmarshal(out, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -1,4 +1,4 @@
-package driver
+package qsd
 
 import "testing"
 
@@ -8,10 +8,10 @@ func Test_parseSizeToByteString(t *testing.T) {
 		want    string
 		wantErr bool
 	}{
-		{"300M", "300000000", false},
-		{"100MB", "100000000", false},
-		{"100GB", "100000000000", false},
-		{"300 M", "300000000", false},
+		{"300M", "314572800", false},
+		{"100MB", "104857600", false},
+		{"100GB", "107374182400", false},
+		{"300 M", "314572800", false},
 		{"300 MM", "", true},
 		{"300 Mi", "", true},
 	}
@@ -0,0 +1,155 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func failedGroupSnapshot(m string, err error) (*ResponseGroupSnapshot, error) {
+	log.Errorf(m)
+	return &ResponseGroupSnapshot{
+		Success: false,
+		Message: m,
+	}, err
+}
+
+// CreateGroupSnapshot snapshots the volumes in a single transaction, the snapshots
+// are crash consistent between each other. The graph of the images is updated for
+// all the volumes or none.
+func (c *Server) CreateGroupSnapshot(ctx context.Context, g *GroupSnapshot) (*ResponseGroupSnapshot, error) {
+	log.Infof("Create group snapshot %s of %d volumes", g.ID, len(g.Snapshots))
+	if len(g.Snapshots) == 0 {
+		errMessage := fmt.Sprintf("Group snapshot %s without volumes", g.ID)
+		return failedGroupSnapshot(errMessage, status.Error(codes.InvalidArgument, errMessage))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var requests []SnapshotRequest
+	// The snapshots of the group share the creation time
+	now := time.Now()
+	images := make(map[string]*QCOWImage)
+	volumes := make(map[string]bool)
+	// A retry finds all the snapshots of the group already created
+	created := 0
+	for _, snapshot := range g.Snapshots {
+		if volumes[snapshot.SourceVolumeID] {
+			errMessage := fmt.Sprintf("Volume %s repeated in the group snapshot %s", snapshot.SourceVolumeID, g.ID)
+			return failedGroupSnapshot(errMessage, status.Error(codes.InvalidArgument, errMessage))
+		}
+		volumes[snapshot.SourceVolumeID] = true
+		if err := c.checkMerge(snapshot.SourceVolumeID); err != nil {
+			return failedGroupSnapshot(err.Error(), err)
+		}
+		dir := fmt.Sprintf("%s/%s", imagesDir, snapshot.SourceVolumeID)
+		if s, ok := c.images[snapshot.ID]; ok {
+			if filepath.Dir(s.File) != dir {
+				errMessage := fmt.Sprintf("Snapshot %s already exists for another volume", snapshot.ID)
+				return failedGroupSnapshot(errMessage, status.Error(codes.AlreadyExists, errMessage))
+			}
+			created++
+			continue
+		}
+		if _, ok := c.internalSnapshots[snapshot.ID]; ok {
+			errMessage := fmt.Sprintf("Snapshot %s already exists", snapshot.ID)
+			return failedGroupSnapshot(errMessage, status.Error(codes.AlreadyExists, errMessage))
+		}
+		id, ok := c.activeLayers[snapshot.SourceVolumeID]
+		if !ok {
+			errMessage := fmt.Sprintf("Volume %s not found", snapshot.SourceVolumeID)
+			return failedGroupSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+		}
+		i, ok := c.images[id]
+		if !ok {
+			errMessage := fmt.Sprintf("Active layer of the volume %s not found", snapshot.SourceVolumeID)
+			return failedGroupSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+		}
+		// The overlay would also replace the image in the volumes cloned from it
+		if i.RefCount > 0 {
+			errMessage := fmt.Sprintf("The active layer of the volume %s is used by other images", snapshot.SourceVolumeID)
+			return failedGroupSnapshot(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+		}
+		if _, err := os.Stat(dir); err != nil {
+			errMessage := fmt.Sprintf("Failed checking the directory for snapshot %s:%v", snapshot.ID, err)
+			return failedGroupSnapshot(errMessage, err)
+		}
+		s := &QCOWImage{
+			QSDID:          generateQSDID(snapshot.ID),
+			BackingImageID: id,
+			File:           fmt.Sprintf("%s/%s-%s", dir, snapshotPrefix, generateQSDID(snapshot.ID)),
+			VolumeRef:      snapshot.ID,
+			Depth:          i.Depth + 1,
+			Options:        i.Options,
+			Created:        now,
+		}
+		b, carryBitmap := c.backups[snapshot.SourceVolumeID]
+		requests = append(requests, SnapshotRequest{
+			ImageID:     i.QSDID,
+			SnapshotID:  s.QSDID,
+			Image:       i.File,
+			Snapshot:    s.File,
+			CarryBitmap: carryBitmap && b.Node == i.QSDID,
+			Options:     s.Options,
+		})
+		images[snapshot.ID] = s
+	}
+	if created == len(g.Snapshots) {
+		log.Infof("Group snapshot %s already created", g.ID)
+		return c.groupSnapshotResponse(ctx, g)
+	}
+	// The snapshots are created in a single transaction, a group with part of
+	// them is another group
+	if created > 0 {
+		errMessage := fmt.Sprintf("Group snapshot %s already exists with different volumes", g.ID)
+		return failedGroupSnapshot(errMessage, status.Error(codes.AlreadyExists, errMessage))
+	}
+	// The mirrors block the snapshots of the active layers
+	for _, snapshot := range g.Snapshots {
+		paused, err := c.pauseReplication(ctx, snapshot.SourceVolumeID)
+		if err != nil {
+			errMessage := fmt.Sprintf("Cannot pause the replication of %s: %v", snapshot.SourceVolumeID, err)
+			return failedGroupSnapshot(errMessage, err)
+		}
+		if paused {
+			defer c.resumeReplication(ctx, snapshot.SourceVolumeID)
+		}
+	}
+	if err := c.volManager.CreateSnapshots(ctx, requests); err != nil {
+		errMessage := fmt.Sprintf("Cannot create the group snapshot %s: %v", g.ID, err)
+		return failedGroupSnapshot(errMessage, err)
+	}
+	for k, snapshot := range g.Snapshots {
+		s := images[snapshot.ID]
+		i := c.images[s.BackingImageID]
+		i.RefCount++
+		if requests[k].CarryBitmap {
+			c.backups[snapshot.SourceVolumeID].Node = s.QSDID
+		}
+		c.images[snapshot.ID] = s
+		c.activeLayers[snapshot.SourceVolumeID] = snapshot.ID
+	}
+	return c.groupSnapshotResponse(ctx, g)
+}
+
+// groupSnapshotResponse returns the creation time and the sizes of the snapshots
+// of the group
+func (c *Server) groupSnapshotResponse(ctx context.Context, g *GroupSnapshot) (*ResponseGroupSnapshot, error) {
+	resp := &ResponseGroupSnapshot{Success: true}
+	for _, snapshot := range g.Snapshots {
+		r, err := c.snapshotResponse(ctx, c.images[snapshot.ID])
+		if err != nil {
+			return &ResponseGroupSnapshot{
+				Success: false,
+				Message: r.Message,
+			}, err
+		}
+		resp.Snapshots = append(resp.Snapshots, r)
+	}
+	return resp, nil
+}
@@ -0,0 +1,190 @@
+package qsd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportFormats are the image formats supported by ExportVolume
+var exportFormats = map[string]bool{
+	"qcow2": true,
+	"raw":   true,
+	"vmdk":  true,
+	"vhdx":  true,
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// SetImageDirs configures the directories where the local images are imported from
+// and exported to. The local paths are refused when the directory is empty.
+func (c *Server) SetImageDirs(importDir, exportDir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.importDir = importDir
+	c.exportDir = exportDir
+}
+
+// resolveInDir returns the path with the symlinks resolved if it is inside dir.
+// The file of an export doesn't exist yet, only its directory is resolved.
+func resolveInDir(dir, p string, exists bool) (string, error) {
+	if dir == "" {
+		return "", status.Errorf(codes.InvalidArgument, "The local paths are disabled on the node")
+	}
+	if !filepath.IsAbs(p) {
+		return "", status.Errorf(codes.InvalidArgument, "The path %s needs to be absolute", p)
+	}
+	base, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", status.Errorf(codes.FailedPrecondition, "Cannot resolve the directory %s: %v", dir, err)
+	}
+	var resolved string
+	if exists {
+		resolved, err = filepath.EvalSymlinks(p)
+	} else {
+		resolved, err = filepath.EvalSymlinks(filepath.Dir(p))
+		resolved = filepath.Join(resolved, filepath.Base(p))
+	}
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "Cannot resolve the path %s: %v", p, err)
+	}
+	rel, err := filepath.Rel(base, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", status.Errorf(codes.PermissionDenied, "The path %s is outside of %s", p, dir)
+	}
+	return resolved, nil
+}
+
+// ImportVolume creates a new volume from an external image. The image is converted
+// to qcow2 by qemu-img and the progress of the conversion is streamed to the client.
+func (c *Server) ImportVolume(p *ImportParams, stream QsdService_ImportVolumeServer) error {
+	log.Infof("Import volume %s from %s", p.VolumeID, p.Source)
+	ctx := stream.Context()
+	if p.VolumeID == "" {
+		return status.Error(codes.InvalidArgument, "The volume ID cannot be empty")
+	}
+	if err := checkOptions(p.Export, p.IO); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	source := p.Source
+	// The local images can only be read from the import directory
+	if !isURL(source) {
+		var err error
+		if source, err = resolveInDir(c.importDir, p.Source, true); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+	}
+	if _, ok := c.images[p.VolumeID]; ok {
+		c.mu.Unlock()
+		return status.Errorf(codes.AlreadyExists, "Volume %s already exists", p.VolumeID)
+	}
+	if err := c.reserve(p.VolumeID); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	defer c.release(p.VolumeID)
+	c.mu.Unlock()
+
+	dir := fmt.Sprintf("%s/%s", imagesDir, p.VolumeID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Cannot create directory for the volume %s: %v", p.VolumeID, err)
+	}
+	qcowImage := &QCOWImage{
+		File:      fmt.Sprintf("%s/%s", dir, diskImg),
+		QSDID:     generateQSDID(p.VolumeID),
+		VolumeRef: p.VolumeID,
+		PVC:       pvcName(&Image{PVCName: p.PVCName, PVCNamespace: p.PVCNamespace}),
+		Options:   nodeOptions(p.Export, p.IO),
+		Export:    p.Export,
+		Created:   time.Now(),
+	}
+	if err := ImportImage(ctx, source, p.Format, qcowImage.File, sendProgress(stream)); err != nil {
+		os.RemoveAll(dir)
+		log.Errorf("Failed importing %s: %v", p.Source, err)
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.volManager.AddImage(ctx, qcowImage.File, qcowImage.QSDID, "", qcowImage.Options); err != nil {
+		os.RemoveAll(dir)
+		log.Errorf("Failed adding the imported volume %s: %v", p.VolumeID, err)
+		return err
+	}
+	c.images[p.VolumeID] = qcowImage
+	c.activeLayers[p.VolumeID] = p.VolumeID
+	return stream.Send(&Progress{Percent: 100, Done: true})
+}
+
+// ExportVolume copies the active layer of the volume in an image on the node. The
+// copy is taken with a block job, the volume can stay in use.
+func (c *Server) ExportVolume(p *ExportParams, stream QsdService_ExportVolumeServer) error {
+	log.Infof("Export volume %s to %s", p.VolumeID, p.Destination)
+	ctx := stream.Context()
+	format := p.Format
+	if format == "" {
+		format = "qcow2"
+	}
+	if !exportFormats[format] {
+		return status.Errorf(codes.InvalidArgument, "Format %s not supported", format)
+	}
+	c.mu.Lock()
+	// The images are written as root, only in the export directory
+	dest, err := resolveInDir(c.exportDir, p.Destination, false)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	if _, err := os.Lstat(dest); err == nil {
+		c.mu.Unlock()
+		return status.Errorf(codes.AlreadyExists, "The destination %s already exists", p.Destination)
+	}
+	a, ok := c.images[c.activeLayers[p.VolumeID]]
+	if !ok {
+		c.mu.Unlock()
+		return status.Errorf(codes.NotFound, "Volume %s not found", p.VolumeID)
+	}
+	if err := c.reserve(p.VolumeID); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	defer c.release(p.VolumeID)
+	volManager := c.volManager
+	c.mu.Unlock()
+
+	n, err := volManager.GetNode(ctx, a.QSDID)
+	if err != nil {
+		return fmt.Errorf("Failed getting the node of the volume %s: %v", p.VolumeID, err)
+	}
+	if err := CreateImageFormat(ctx, dest, format, int64(n.Image.VirtualSize)); err != nil {
+		return err
+	}
+	if err := volManager.ExportNode(ctx, a.QSDID, dest, format, sendProgress(stream)); err != nil {
+		os.Remove(dest)
+		log.Errorf("Failed exporting the volume %s: %v", p.VolumeID, err)
+		return err
+	}
+	return stream.Send(&Progress{Percent: 100, Done: true})
+}
+
+type progressSender interface {
+	Send(*Progress) error
+}
+
+func sendProgress(stream progressSender) func(float32) {
+	return func(p float32) {
+		if err := stream.Send(&Progress{Percent: p}); err != nil {
+			log.Warningf("Failed sending the progress: %v", err)
+		}
+	}
+}
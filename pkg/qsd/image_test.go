@@ -0,0 +1,63 @@
+package qsd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveInDir(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "import")
+	outside := filepath.Join(tmp, "outside")
+	for _, d := range []string{dir, outside} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{filepath.Join(dir, "disk.qcow2"), filepath.Join(outside, "secret.img")} {
+		if err := ioutil.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.img"), filepath.Join(dir, "link.img")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "linkdir")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		dir    string
+		path   string
+		exists bool
+		code   codes.Code
+	}{
+		{name: "file in the directory", dir: dir, path: filepath.Join(dir, "disk.qcow2"), exists: true, code: codes.OK},
+		{name: "new file in the directory", dir: dir, path: filepath.Join(dir, "new.qcow2"), code: codes.OK},
+		{name: "disabled", dir: "", path: filepath.Join(dir, "disk.qcow2"), exists: true, code: codes.InvalidArgument},
+		{name: "relative path", dir: dir, path: "disk.qcow2", exists: true, code: codes.InvalidArgument},
+		{name: "file outside", dir: dir, path: filepath.Join(outside, "secret.img"), exists: true, code: codes.PermissionDenied},
+		{name: "dot dot", dir: dir, path: filepath.Join(dir, "..", "outside", "secret.img"), exists: true, code: codes.PermissionDenied},
+		{name: "symlink to a file outside", dir: dir, path: filepath.Join(dir, "link.img"), exists: true, code: codes.PermissionDenied},
+		{name: "new file in a symlinked directory", dir: dir, path: filepath.Join(dir, "linkdir", "new.qcow2"), code: codes.PermissionDenied},
+		{name: "missing file", dir: dir, path: filepath.Join(dir, "missing.qcow2"), exists: true, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveInDir(tt.dir, tt.path, tt.exists)
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("resolveInDir(%q, %q) code %s, want %s: %v", tt.dir, tt.path, code, tt.code, err)
+			}
+		})
+	}
+}
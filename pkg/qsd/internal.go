@@ -0,0 +1,141 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalSnapshot is a snapshot stored in the qcow2 image of a layer of the volume.
+// The layer is kept until all its internal snapshots are deleted.
+type internalSnapshot struct {
+	Volume  string
+	ImageID string
+}
+
+// createInternalSnapshot snapshots the active layer of the volume in its qcow2 image,
+// the backing chain stays flat
+func (c *Server) createInternalSnapshot(ctx context.Context, snapshot *Snapshot) (*ResponseSnapshot, error) {
+	id, ok := c.activeLayers[snapshot.SourceVolumeID]
+	if !ok {
+		errMessage := fmt.Sprintf("Volume %s not found", snapshot.SourceVolumeID)
+		return failedSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	i, ok := c.images[id]
+	if !ok {
+		errMessage := fmt.Sprintf("Active layer of the volume %s not found", snapshot.SourceVolumeID)
+		return failedSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if err := c.volManager.CreateInternalSnapshot(ctx, i.QSDID, snapshot.ID); err != nil {
+		errMessage := fmt.Sprintf("Cannot snapshot %s: %v", snapshot.ID, err)
+		return failedSnapshot(errMessage, err)
+	}
+	s := &internalSnapshot{
+		Volume:  snapshot.SourceVolumeID,
+		ImageID: id,
+	}
+	c.internalSnapshots[snapshot.ID] = s
+	return c.internalSnapshotResponse(ctx, snapshot.ID, s)
+}
+
+// internalSnapshotResponse returns the creation time and the size of the snapshot
+// recorded in the qcow2 image. The clusters of the snapshot are shared with the
+// image, the space allocated only by the snapshot isn't known.
+func (c *Server) internalSnapshotResponse(ctx context.Context, name string, s *internalSnapshot) (*ResponseSnapshot, error) {
+	i, ok := c.images[s.ImageID]
+	if !ok {
+		errMessage := fmt.Sprintf("Image %s of the snapshot %s not found", s.ImageID, name)
+		return failedSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	n, err := c.volManager.GetNode(ctx, i.QSDID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed getting the node of the snapshot %s: %v", name, err)
+		return failedSnapshot(errMessage, err)
+	}
+	for _, info := range n.Image.Snapshots {
+		if info.Name != name {
+			continue
+		}
+		return &ResponseSnapshot{
+			Success:      true,
+			CreationTime: time.Unix(int64(info.DateSec), int64(info.DateNsec)).UnixNano(),
+			RestoreSize:  int64(n.Image.VirtualSize),
+		}, nil
+	}
+	errMessage := fmt.Sprintf("Snapshot %s not found in the image %s", name, i.File)
+	return failedSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+}
+
+// deleteInternalSnapshot removes the snapshot from its image, and the image if it
+// isn't used anymore
+func (c *Server) deleteInternalSnapshot(ctx context.Context, name string, s *internalSnapshot) (*Response, error) {
+	if c.busy[name] {
+		errMessage := fmt.Sprintf("Snapshot %s is being cloned", name)
+		return failed(errMessage, status.Error(codes.Aborted, errMessage))
+	}
+	i, ok := c.images[s.ImageID]
+	if !ok {
+		errMessage := fmt.Sprintf("Image %s of the snapshot %s not found", s.ImageID, name)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if err := c.volManager.DeleteInternalSnapshot(ctx, i.QSDID, name); err != nil {
+		errMessage := fmt.Sprintf("Failed deleting snapshot %s:%v", name, err)
+		return failed(errMessage, err)
+	}
+	delete(c.internalSnapshots, name)
+	if err := c.deleteNodeWithZeroReference(ctx, s.ImageID); err != nil {
+		errMessage := fmt.Sprintf("Failed cleaning up the zero reference node %s: %v", s.ImageID, err)
+		return failed(errMessage, err)
+	}
+	return &Response{}, nil
+}
+
+// cloneInternalSnapshot creates the standalone image of the volume with the content
+// of the internal snapshot. The conversion can take a while, c.mu is released in
+// the meantime and the volume and the snapshot are reserved.
+func (c *Server) cloneInternalSnapshot(ctx context.Context, name string, s *internalSnapshot, qcowImage *QCOWImage) error {
+	i, ok := c.images[s.ImageID]
+	if !ok {
+		return fmt.Errorf("Image %s of the snapshot %s not found", s.ImageID, name)
+	}
+	if err := c.reserve(qcowImage.VolumeRef); err != nil {
+		return err
+	}
+	if err := c.reserve(name); err != nil {
+		delete(c.busy, qcowImage.VolumeRef)
+		return err
+	}
+	file, volManager := i.File, c.volManager
+	c.mu.Unlock()
+	err := convertInternalSnapshot(ctx, volManager, file, name, qcowImage)
+	c.mu.Lock()
+	delete(c.busy, qcowImage.VolumeRef)
+	delete(c.busy, name)
+	return err
+}
+
+func convertInternalSnapshot(ctx context.Context, volManager *VolumeManager, file, name string, qcowImage *QCOWImage) error {
+	if err := ConvertSnapshot(ctx, file, name, qcowImage.File); err != nil {
+		os.Remove(qcowImage.File)
+		return err
+	}
+	if err := volManager.AddImage(ctx, qcowImage.File, qcowImage.QSDID, "", qcowImage.Options); err != nil {
+		os.Remove(qcowImage.File)
+		return err
+	}
+	return nil
+}
+
+// hasInternalSnapshots returns if the image stores internal snapshots
+func (c *Server) hasInternalSnapshots(id string) bool {
+	for _, s := range c.internalSnapshots {
+		if s.ImageID == id {
+			return true
+		}
+	}
+	return false
+}
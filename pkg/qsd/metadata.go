@@ -0,0 +1,122 @@
+package qsd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxResults is the number of extents per page if not set by the client
+const defaultMaxResults = 256
+
+// SnapshotMetadata streams the extents allocated in the snapshot or, with a base
+// snapshot, the extents written between the two snapshots. The extents are computed
+// with qemu-img map on the backing image of the snapshot overlay, the layers above
+// the content of the base snapshot contain the changed extents.
+func (c *Server) SnapshotMetadata(p *SnapshotMetadataParams, stream QsdService_SnapshotMetadataServer) error {
+	log.Infof("Metadata of snapshot %s from %q", p.SnapshotID, p.BaseSnapshotID)
+	ctx := stream.Context()
+	if p.StartingOffset < 0 || p.MaxResults < 0 {
+		return status.Error(codes.InvalidArgument, "The starting offset and the max results cannot be negative")
+	}
+	c.mu.Lock()
+	s, ok := c.images[p.SnapshotID]
+	if !ok {
+		c.mu.Unlock()
+		return status.Errorf(codes.NotFound, "Snapshot %s not found", p.SnapshotID)
+	}
+	b, ok := c.images[s.BackingImageID]
+	if !ok {
+		c.mu.Unlock()
+		return status.Errorf(codes.NotFound, "Backing image of the snapshot %s not found", p.SnapshotID)
+	}
+	// All the allocated extents are reported without base snapshot
+	depth := -1
+	if p.BaseSnapshotID != "" {
+		base, ok := c.images[p.BaseSnapshotID]
+		if !ok {
+			c.mu.Unlock()
+			return status.Errorf(codes.NotFound, "Snapshot %s not found", p.BaseSnapshotID)
+		}
+		if depth, ok = c.chainDistance(s.BackingImageID, base.BackingImageID); !ok {
+			c.mu.Unlock()
+			return status.Errorf(codes.InvalidArgument, "Snapshot %s is not a previous snapshot of %s", p.BaseSnapshotID, p.SnapshotID)
+		}
+	}
+	node, file, volManager := b.QSDID, b.File, c.volManager
+	c.mu.Unlock()
+
+	n, err := volManager.GetNode(ctx, node)
+	if err != nil {
+		return fmt.Errorf("Failed getting the node of the snapshot %s: %v", p.SnapshotID, err)
+	}
+	entries, err := MapImage(ctx, file)
+	if err != nil {
+		return fmt.Errorf("Failed mapping the snapshot %s: %v", p.SnapshotID, err)
+	}
+	extents := changedExtents(entries, depth, p.StartingOffset)
+	max := int(p.MaxResults)
+	if max == 0 {
+		max = defaultMaxResults
+	}
+	// The first page is sent also without extents to report the capacity
+	for first := true; first || len(extents) > 0; first = false {
+		size := max
+		if len(extents) < size {
+			size = len(extents)
+		}
+		if err := stream.Send(&BlockMetadataPage{
+			VolumeCapacity: int64(n.Image.VirtualSize),
+			Extents:        extents[:size],
+		}); err != nil {
+			return err
+		}
+		extents = extents[size:]
+	}
+	return nil
+}
+
+// chainDistance returns the number of layers between the image top and its backing
+// image id
+func (c *Server) chainDistance(top, id string) (int, bool) {
+	for d := 0; top != ""; d++ {
+		if top == id {
+			return d, true
+		}
+		i, ok := c.images[top]
+		if !ok {
+			return 0, false
+		}
+		top = i.BackingImageID
+	}
+	return 0, false
+}
+
+// changedExtents returns the extents starting from offset with data or, if depth
+// isn't negative, allocated in the layers above depth. The adjacent extents are merged.
+func changedExtents(entries []MapEntry, depth int, offset int64) []*BlockExtent {
+	var extents []*BlockExtent
+	for _, e := range entries {
+		if depth < 0 && !e.Data {
+			continue
+		}
+		if depth >= 0 && (!e.Present || e.Depth >= depth) {
+			continue
+		}
+		start, end := e.Start, e.Start+e.Length
+		if end <= offset {
+			continue
+		}
+		if start < offset {
+			start = offset
+		}
+		if l := len(extents); l > 0 && extents[l-1].Offset+extents[l-1].Length == start {
+			extents[l-1].Length = end - extents[l-1].Offset
+			continue
+		}
+		extents = append(extents, &BlockExtent{Offset: start, Length: end - start})
+	}
+	return extents
+}
@@ -0,0 +1,101 @@
+package qsd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChainDistance(t *testing.T) {
+	// base <- snap1 <- snap2 <- volume, other is in another chain
+	c := &Server{images: map[string]*QCOWImage{
+		"base":   {},
+		"snap1":  {BackingImageID: "base"},
+		"snap2":  {BackingImageID: "snap1"},
+		"volume": {BackingImageID: "snap2"},
+		"other":  {},
+		"broken": {BackingImageID: "missing"},
+	}}
+	tests := []struct {
+		name     string
+		top      string
+		id       string
+		distance int
+		found    bool
+	}{
+		{name: "same image", top: "snap2", id: "snap2", distance: 0, found: true},
+		{name: "backing image", top: "volume", id: "snap2", distance: 1, found: true},
+		{name: "base", top: "volume", id: "base", distance: 3, found: true},
+		{name: "image above", top: "snap1", id: "volume"},
+		{name: "other chain", top: "volume", id: "other"},
+		{name: "missing backing image", top: "broken", id: "base"},
+		{name: "missing top", top: "missing", id: "base"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, found := c.chainDistance(tt.top, tt.id)
+			if distance != tt.distance || found != tt.found {
+				t.Errorf("chainDistance(%q, %q) = %d, %v, want %d, %v", tt.top, tt.id, distance, found, tt.distance, tt.found)
+			}
+		})
+	}
+}
+
+func TestChangedExtents(t *testing.T) {
+	entries := []MapEntry{
+		{Start: 0, Length: 100, Depth: 0, Present: true, Data: true},
+		{Start: 100, Length: 100, Depth: 1, Present: true, Data: true},
+		{Start: 200, Length: 100, Depth: 2, Present: true, Zero: true},
+		{Start: 300, Length: 100, Depth: 0, Present: false},
+		{Start: 400, Length: 100, Depth: 1, Present: true, Data: true},
+	}
+	tests := []struct {
+		name   string
+		depth  int
+		offset int64
+		want   []*BlockExtent
+	}{
+		{
+			name:  "allocated",
+			depth: -1,
+			want:  []*BlockExtent{{Offset: 0, Length: 200}, {Offset: 400, Length: 100}},
+		},
+		{
+			name:   "allocated from offset",
+			depth:  -1,
+			offset: 150,
+			want:   []*BlockExtent{{Offset: 150, Length: 50}, {Offset: 400, Length: 100}},
+		},
+		{
+			name:  "changed in the top layer",
+			depth: 1,
+			want:  []*BlockExtent{{Offset: 0, Length: 100}},
+		},
+		{
+			name:  "changed in two layers",
+			depth: 2,
+			want:  []*BlockExtent{{Offset: 0, Length: 200}, {Offset: 400, Length: 100}},
+		},
+		{
+			name:  "zeroes written above the base",
+			depth: 3,
+			want:  []*BlockExtent{{Offset: 0, Length: 300}, {Offset: 400, Length: 100}},
+		},
+		{
+			name:  "same snapshot",
+			depth: 0,
+		},
+		{
+			name:   "offset after the extents",
+			depth:  -1,
+			offset: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changedExtents(entries, tt.depth, tt.offset)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changedExtents(%d, %d) = %v, want %v", tt.depth, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
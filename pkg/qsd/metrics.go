@@ -0,0 +1,272 @@
+package qsd
+
+import (
+	context "context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const metricsNamespace = "qsd"
+
+var (
+	qmpCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "qmp_command_duration_seconds",
+		Help:      "Latency of the QMP commands executed on the qemu-storage-daemon",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 10),
+	}, []string{"command"})
+
+	qmpCommandErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "qmp_command_errors_total",
+		Help:      "Number of QMP commands that returned an error",
+	}, []string{"command"})
+)
+
+var (
+	volumeLabels = []string{"volume", "pvc", "node"}
+
+	readBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "read_bytes_total"),
+		"Number of bytes read from the volume", volumeLabels, nil)
+	writeBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "write_bytes_total"),
+		"Number of bytes written to the volume", volumeLabels, nil)
+	readOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "read_operations_total"),
+		"Number of read operations on the volume", volumeLabels, nil)
+	writeOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "write_operations_total"),
+		"Number of write operations on the volume", volumeLabels, nil)
+	flushOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "flush_operations_total"),
+		"Number of flush operations on the volume", volumeLabels, nil)
+	failedReadOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "failed_read_operations_total"),
+		"Number of failed read operations on the volume", volumeLabels, nil)
+	failedWriteOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "failed_write_operations_total"),
+		"Number of failed write operations on the volume", volumeLabels, nil)
+	readLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "read_latency_seconds"),
+		"Latency histogram of the read operations on the volume", volumeLabels, nil)
+	writeLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "write_latency_seconds"),
+		"Latency histogram of the write operations on the volume", volumeLabels, nil)
+	avgReadLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "avg_read_latency_seconds"),
+		"Average read latency over the stats interval", append(volumeLabels, "interval"), nil)
+	avgWriteLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "avg_write_latency_seconds"),
+		"Average write latency over the stats interval", append(volumeLabels, "interval"), nil)
+	writeHighestOffsetDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "write_highest_offset_bytes"),
+		"Highest offset written in the node of the volume", volumeLabels, nil)
+	exportsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "exports"),
+		"Number of block exports serving the volume", []string{"volume", "pvc", "type"}, nil)
+	chainDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", "chain_depth"),
+		"Depth of the backing chain of the active layer of the volume", []string{"volume", "pvc"}, nil)
+	jobProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "job", "progress_ratio"),
+		"Progress of the block jobs running on the qemu-storage-daemon", []string{"job", "type", "status"}, nil)
+	replicationLastSyncDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "replication", "last_sync_timestamp_seconds"),
+		"Time of the last successful sync of the asynchronous replica of the volume", []string{"volume", "pvc", "peer"}, nil)
+)
+
+// nodeInfo identifies the volume a block node belongs to
+type nodeInfo struct {
+	volume string
+	pvc    string
+}
+
+// Collector exports the statistics of the qemu-storage-daemon as prometheus metrics.
+// The statistics are gathered through QMP every time the metrics are scraped.
+type Collector struct {
+	server *Server
+}
+
+func NewCollector(server *Server) *Collector {
+	return &Collector{server: server}
+}
+
+// RegisterMetrics registers the QMP metrics and the collector for the server
+func RegisterMetrics(registry prometheus.Registerer, server *Server) error {
+	for _, c := range []prometheus.Collector{qmpCommandDuration, qmpCommandErrors, NewCollector(server)} {
+		if err := registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		readBytesDesc,
+		writeBytesDesc,
+		readOpsDesc,
+		writeOpsDesc,
+		flushOpsDesc,
+		failedReadOpsDesc,
+		failedWriteOpsDesc,
+		readLatencyDesc,
+		writeLatencyDesc,
+		avgReadLatencyDesc,
+		avgWriteLatencyDesc,
+		writeHighestOffsetDesc,
+		exportsDesc,
+		chainDepthDesc,
+		jobProgressDesc,
+		replicationLastSyncDesc,
+	} {
+		ch <- d
+	}
+}
+
+// nodes maps the node names to the volume they belong to and collects the
+// depth of the active layer and the last sync of the replica of each volume
+func (c *Collector) nodes(ch chan<- prometheus.Metric) (map[string]nodeInfo, *VolumeManager) {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+	nodes := make(map[string]nodeInfo)
+	for id, i := range c.server.images {
+		nodes[FormatNode(i.QSDID)] = nodeInfo{volume: id, pvc: i.PVC}
+	}
+	for volume, layer := range c.server.activeLayers {
+		i, ok := c.server.images[layer]
+		if !ok {
+			continue
+		}
+		var pvc string
+		if v, ok := c.server.images[volume]; ok {
+			pvc = v.PVC
+		}
+		// The active layer receives the I/O of the volume
+		nodes[FormatNode(i.QSDID)] = nodeInfo{volume: volume, pvc: pvc}
+		ch <- prometheus.MustNewConstMetric(chainDepthDesc, prometheus.GaugeValue, float64(i.Depth), volume, pvc)
+	}
+	for volume, r := range c.server.replications {
+		if !r.Async || r.LastSync.IsZero() {
+			continue
+		}
+		var pvc string
+		if v, ok := c.server.images[volume]; ok {
+			pvc = v.PVC
+		}
+		ch <- prometheus.MustNewConstMetric(replicationLastSyncDesc, prometheus.GaugeValue, float64(r.LastSync.Unix()), volume, pvc, r.Host)
+	}
+	return nodes, c.server.volManager
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+	defer cancel()
+	nodes, v := c.nodes(ch)
+	c.collectBlockStats(ctx, ch, v, nodes)
+	c.collectExports(ctx, ch, v, nodes)
+	c.collectJobs(ctx, ch, v)
+}
+
+func (c *Collector) collectBlockStats(ctx context.Context, ch chan<- prometheus.Metric, v *VolumeManager, nodes map[string]nodeInfo) {
+	stats, err := v.GetBlockStats(ctx)
+	if err != nil {
+		log.Errorf("Failed collecting the block stats: %v", err)
+		return
+	}
+	for _, s := range stats {
+		n, ok := nodes[s.NodeName]
+		if !ok {
+			continue
+		}
+		labels := []string{n.volume, n.pvc, s.NodeName}
+		counters := []struct {
+			desc  *prometheus.Desc
+			value uint64
+		}{
+			{readBytesDesc, s.Stats.RdBytes},
+			{writeBytesDesc, s.Stats.WrBytes},
+			{readOpsDesc, s.Stats.RdOperations},
+			{writeOpsDesc, s.Stats.WrOperations},
+			{flushOpsDesc, s.Stats.FlushOperations},
+			{failedReadOpsDesc, s.Stats.FailedRdOperations},
+			{failedWriteOpsDesc, s.Stats.FailedWrOperations},
+		}
+		for _, m := range counters {
+			ch <- prometheus.MustNewConstMetric(m.desc, prometheus.CounterValue, float64(m.value), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(writeHighestOffsetDesc, prometheus.GaugeValue,
+			float64(s.Stats.WrHighestOffset), labels...)
+		if h := s.Stats.RdLatencyHistogram; h != nil {
+			ch <- latencyHistogram(readLatencyDesc, h, s.Stats.RdTotalTimeNs, labels)
+		}
+		if h := s.Stats.WrLatencyHistogram; h != nil {
+			ch <- latencyHistogram(writeLatencyDesc, h, s.Stats.WrTotalTimeNs, labels)
+		}
+		// The timed stats are only reported when the stats-intervals are configured
+		for _, t := range s.Stats.TimedStats {
+			interval := strconv.FormatInt(t.IntervalLength, 10)
+			ch <- prometheus.MustNewConstMetric(avgReadLatencyDesc, prometheus.GaugeValue,
+				float64(t.AvgRdLatencyNs)/1e9, append(labels, interval)...)
+			ch <- prometheus.MustNewConstMetric(avgWriteLatencyDesc, prometheus.GaugeValue,
+				float64(t.AvgWrLatencyNs)/1e9, append(labels, interval)...)
+		}
+	}
+}
+
+// latencyHistogram converts the QMP latency histogram in a cumulative prometheus histogram.
+// QMP reports len(boundaries)+1 bins where the bin i counts the operations with
+// a latency in [boundaries[i-1], boundaries[i]) nanoseconds.
+func latencyHistogram(desc *prometheus.Desc, h *LatencyHistogram, totalNs uint64, labels []string) prometheus.Metric {
+	buckets := make(map[float64]uint64)
+	var count uint64
+	for i, b := range h.Bins {
+		count += b
+		if i < len(h.Boundaries) {
+			buckets[float64(h.Boundaries[i])/1e9] = count
+		}
+	}
+	return prometheus.MustNewConstHistogram(desc, count, float64(totalNs)/1e9, buckets, labels...)
+}
+
+func (c *Collector) collectExports(ctx context.Context, ch chan<- prometheus.Metric, v *VolumeManager, nodes map[string]nodeInfo) {
+	exports, err := v.GetBlockExports(ctx)
+	if err != nil {
+		log.Errorf("Failed collecting the block exports: %v", err)
+		return
+	}
+	type exportKey struct {
+		node nodeInfo
+		typ  string
+	}
+	count := make(map[exportKey]int)
+	for _, e := range exports {
+		n, ok := nodes[e.NodeName]
+		if !ok || e.ShuttingDown {
+			continue
+		}
+		count[exportKey{node: n, typ: e.Type}]++
+	}
+	for k, v := range count {
+		ch <- prometheus.MustNewConstMetric(exportsDesc, prometheus.GaugeValue, float64(v), k.node.volume, k.node.pvc, k.typ)
+	}
+}
+
+func (c *Collector) collectJobs(ctx context.Context, ch chan<- prometheus.Metric, v *VolumeManager) {
+	jobs, err := v.GetJobs(ctx)
+	if err != nil {
+		log.Errorf("Failed collecting the jobs: %v", err)
+		return
+	}
+	for _, j := range jobs {
+		var progress float64
+		if j.TotalProgress > 0 {
+			progress = float64(j.CurrentProgress) / float64(j.TotalProgress)
+		}
+		ch <- prometheus.MustNewConstMetric(jobProgressDesc, prometheus.GaugeValue, progress,
+			j.ID, j.Type, j.Status)
+	}
+}
@@ -0,0 +1,209 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultNBDPort = "10809"
+
+// SetNBD configures the port of the NBD server and the directory with the x509
+// certificates, the NBD connections are unencrypted if tlsDir is empty
+func (c *Server) SetNBD(port, tlsDir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nbdPort = port
+	c.nbdTLSDir = tlsDir
+}
+
+func failedNBDExport(m string, err error) (*ResponseNBDExport, error) {
+	log.Errorf(m)
+	return &ResponseNBDExport{
+		Success: false,
+		Message: m,
+	}, err
+}
+
+// ExportNBD exports read-only over NBD the active layer of a volume or a snapshot
+// to copy it on another node
+func (c *Server) ExportNBD(ctx context.Context, image *Image) (*ResponseNBDExport, error) {
+	log.Infof("Export %s over NBD", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i, ok := c.images[c.activeLayers[image.ID]]
+	if !ok {
+		// The overlay of a snapshot is the next layer of the volume, the content
+		// of the snapshot is its backing image
+		if s, found := c.images[image.ID]; found && s.VolumeRef == image.ID {
+			i, ok = c.images[s.BackingImageID]
+		}
+	}
+	if !ok {
+		errMessage := fmt.Sprintf("Image %s not found", image.ID)
+		return failedNBDExport(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	n, err := c.volManager.GetNode(ctx, i.QSDID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed getting the node of %s: %v", image.ID, err)
+		return failedNBDExport(errMessage, err)
+	}
+	if err := c.volManager.StartNBDServer(ctx, c.nbdPort, c.nbdTLSDir); err != nil {
+		errMessage := fmt.Sprintf("Failed starting the NBD server: %v", err)
+		return failedNBDExport(errMessage, err)
+	}
+	// The export is shared by the concurrent copies of the same source
+	if c.nbdExports[image.ID] == 0 {
+		if err := c.volManager.ExposeNBD(ctx, image.ID, i.QSDID); err != nil {
+			errMessage := fmt.Sprintf("Cannot export %s over NBD: %v", image.ID, err)
+			return failedNBDExport(errMessage, err)
+		}
+	}
+	c.nbdExports[image.ID]++
+	return &ResponseNBDExport{
+		Success:    true,
+		Port:       c.nbdPort,
+		ExportName: image.ID,
+		Size:       int64(n.Image.VirtualSize),
+		TLS:        c.nbdTLSDir != "",
+	}, nil
+}
+
+func (c *Server) DeleteNBDExport(ctx context.Context, image *Image) (*Response, error) {
+	log.Infof("Delete the NBD export of %s", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nbdExports[image.ID] == 0 {
+		return &Response{Success: true}, nil
+	}
+	if c.nbdExports[image.ID] > 1 {
+		c.nbdExports[image.ID]--
+		return &Response{Success: true}, nil
+	}
+	if err := c.volManager.DeleteNBDExport(ctx, image.ID); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete the NBD export of %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	delete(c.nbdExports, image.ID)
+	return &Response{Success: true}, nil
+}
+
+// MigrateVolume creates the volume from the NBD export of another qsd server. The
+// data is copied by a mirror job from the NBD client node into a new image, the
+// source must not be modified during the copy.
+func (c *Server) MigrateVolume(ctx context.Context, p *MigrateParams) (*Response, error) {
+	log.Infof("Migrate volume %s from %s:%s", p.VolumeID, p.Host, p.Port)
+	if err := checkOptions(p.Export, p.IO); err != nil {
+		return failed(err.Error(), err)
+	}
+	c.mu.Lock()
+	if _, ok := c.images[p.VolumeID]; ok {
+		c.mu.Unlock()
+		errMessage := fmt.Sprintf("Volume %s already exists", p.VolumeID)
+		return failed(errMessage, status.Error(codes.AlreadyExists, errMessage))
+	}
+	if err := c.reserve(p.VolumeID); err != nil {
+		c.mu.Unlock()
+		return failed(err.Error(), err)
+	}
+	defer c.release(p.VolumeID)
+	tlsDir := ""
+	if p.TLS {
+		if c.nbdTLSDir == "" {
+			c.mu.Unlock()
+			errMessage := "The source requires TLS but the certificates are not configured"
+			return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+		}
+		tlsDir = c.nbdTLSDir
+	}
+	volManager := c.volManager
+	c.mu.Unlock()
+
+	dir := fmt.Sprintf("%s/%s", imagesDir, p.VolumeID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create directory for the volume:%s", p.VolumeID)
+		return failed(errMessage, err)
+	}
+	qcowImage := &QCOWImage{
+		File:      fmt.Sprintf("%s/%s", dir, diskImg),
+		QSDID:     generateQSDID(p.VolumeID),
+		VolumeRef: p.VolumeID,
+		PVC:       pvcName(&Image{PVCName: p.PVCName, PVCNamespace: p.PVCNamespace}),
+		Options:   nodeOptions(p.Export, p.IO),
+		Export:    p.Export,
+		Created:   time.Now(),
+	}
+	if err := c.copyFromNBD(ctx, volManager, qcowImage, p, tlsDir); err != nil {
+		os.RemoveAll(dir)
+		errMessage := fmt.Sprintf("Failed migrating the volume %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.images[p.VolumeID] = qcowImage
+	c.activeLayers[p.VolumeID] = p.VolumeID
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+func (c *Server) copyFromNBD(ctx context.Context, volManager *VolumeManager, i *QCOWImage, p *MigrateParams, tlsDir string) (err error) {
+	if err := volManager.CreateVolume(ctx, i.File, i.QSDID, fmt.Sprintf("%d", p.Size), i.Options); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if err := volManager.DeleteVolume(context.Background(), i.QSDID); err != nil {
+				log.Errorf("Failed removing the node of %s: %v", p.VolumeID, err)
+			}
+		}
+	}()
+	if err := volManager.AddNBDNode(ctx, i.QSDID, p.Host, p.Port, p.ExportName, tlsDir); err != nil {
+		return err
+	}
+	defer func() {
+		if err := volManager.DeleteNBDNode(context.Background(), i.QSDID); err != nil {
+			log.Errorf("Failed removing the NBD node of %s: %v", p.VolumeID, err)
+		}
+	}()
+	return volManager.MirrorFromNBD(ctx, i.QSDID)
+}
+
+// TransferVolume copies the volume or the snapshot sourceID from the src server in
+// the new volume volumeID on the dst server. srcHost is the address where dst
+// reaches the NBD server of src.
+func TransferVolume(ctx context.Context, src, dst QsdServiceClient, srcHost, sourceID string, volume *Image) error {
+	e, err := src.ExportNBD(ctx, &Image{ID: sourceID})
+	if err != nil {
+		return fmt.Errorf("Failed exporting %s over NBD: %v", sourceID, err)
+	}
+	defer func() {
+		if _, err := src.DeleteNBDExport(context.Background(), &Image{ID: sourceID}); err != nil {
+			log.Errorf("Failed deleting the NBD export of %s: %v", sourceID, err)
+		}
+	}()
+	_, err = dst.MigrateVolume(ctx, &MigrateParams{
+		VolumeID:     volume.ID,
+		Host:         srcHost,
+		Port:         e.Port,
+		ExportName:   e.ExportName,
+		Size:         e.Size,
+		TLS:          e.TLS,
+		PVCName:      volume.PVCName,
+		PVCNamespace: volume.PVCNamespace,
+		Export:       volume.Export,
+		IO:           volume.IO,
+	})
+	if err != nil {
+		// Keep the status of the error, the volume could already exist
+		log.Errorf("Failed copying %s: %v", sourceID, err)
+		return err
+	}
+	return nil
+}
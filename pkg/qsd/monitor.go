@@ -0,0 +1,276 @@
+package qsd
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/go-qemu/qmp"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// connectTimeout bounds the dial and the capabilities handshake with the monitor
+	connectTimeout      = 5 * time.Second
+	maxReconnectBackoff = 10 * time.Second
+)
+
+// CommandTimeout is the timeout applied to the QMP commands when the caller
+// context has no deadline
+var CommandTimeout = 30 * time.Second
+
+// ErrMonitorUnavailable is returned while the connection with the monitor is broken
+var ErrMonitorUnavailable = status.Error(codes.Unavailable, "the QMP monitor of the qemu-storage-daemon is reconnecting")
+
+// QMPMonitor wraps the QMP socket of the qemu-storage-daemon. The commands honor
+// the context of the caller and, when the connection breaks, the monitor reconnects
+// in background.
+type QMPMonitor struct {
+	socket string
+	// connMu serializes the attempts of connecting to the socket
+	connMu sync.Mutex
+	// mu protects the fields below
+	mu          sync.Mutex
+	monitor     *qmp.SocketMonitor
+	connected   bool
+	closed      bool
+	subscribers map[chan qmp.Event]struct{}
+	broken      chan struct{}
+}
+
+func CreateNewUnixMonitor(socket string) (*QMPMonitor, error) {
+	q := &QMPMonitor{
+		socket:      socket,
+		subscribers: make(map[chan qmp.Event]struct{}),
+		broken:      make(chan struct{}, 1),
+	}
+	if err := q.connect(); err != nil {
+		return &QMPMonitor{}, err
+	}
+	go q.reconnectLoop()
+	return q, nil
+}
+
+// connect opens a new connection with the monitor and replaces the current one
+func (q *QMPMonitor) connect() error {
+	q.connMu.Lock()
+	defer q.connMu.Unlock()
+	m, err := qmp.NewSocketMonitor("unix", q.socket, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("Fail in creating qmp connection: %v", err)
+	}
+	// The daemon accepts a single connection on the monitor and the handshake
+	// blocks until the previous client disconnects
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Connect()
+	}()
+	select {
+	case err = <-done:
+	case <-time.After(connectTimeout):
+		m.Disconnect()
+		err = fmt.Errorf("Timeout the communication with the qmp socket %s", q.socket)
+	}
+	if err != nil {
+		m.Disconnect()
+		return err
+	}
+	events, err := m.Events()
+	if err != nil {
+		m.Disconnect()
+		return err
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		m.Disconnect()
+		return fmt.Errorf("The monitor has been closed")
+	}
+	old := q.monitor
+	q.monitor = m
+	q.connected = true
+	q.mu.Unlock()
+	if old != nil {
+		old.Disconnect()
+	}
+	go q.pumpEvents(m, events)
+	log.Infof("Connected to the QMP monitor %s", q.socket)
+	return nil
+}
+
+// pumpEvents dispatches the events of the connection to the subscribers. The
+// events channel is closed when the connection drops.
+func (q *QMPMonitor) pumpEvents(m *qmp.SocketMonitor, events <-chan qmp.Event) {
+	for e := range events {
+		q.mu.Lock()
+		for s := range q.subscribers {
+			select {
+			case s <- e:
+			default:
+				log.Warningf("Dropped event %s for a slow subscriber", e.Event)
+			}
+		}
+		q.mu.Unlock()
+	}
+	q.markBroken(m)
+}
+
+// markBroken flags the connection as broken and wakes up the reconnect loop.
+// Connections that have already been replaced are ignored.
+func (q *QMPMonitor) markBroken(m *qmp.SocketMonitor) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.monitor != m || !q.connected || q.closed {
+		return
+	}
+	log.Warningf("Lost the connection with the QMP monitor %s", q.socket)
+	q.connected = false
+	m.Disconnect()
+	select {
+	case q.broken <- struct{}{}:
+	default:
+	}
+}
+
+func (q *QMPMonitor) reconnectLoop() {
+	for range q.broken {
+		backoff := 100 * time.Millisecond
+		for {
+			q.mu.Lock()
+			done := q.connected || q.closed
+			q.mu.Unlock()
+			if done {
+				break
+			}
+			if err := q.connect(); err == nil {
+				break
+			} else {
+				log.Warningf("Failed reconnecting to the QMP monitor: %v", err)
+			}
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+		}
+	}
+}
+
+// Reconnect replaces the current connection with a new one, for example after
+// the daemon has been restarted
+func (q *QMPMonitor) Reconnect() error {
+	return q.connect()
+}
+
+func (q *QMPMonitor) Disconnect() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.connected = false
+	if q.monitor != nil {
+		q.monitor.Disconnect()
+	}
+	close(q.broken)
+}
+
+// current returns the connection if it is usable
+func (q *QMPMonitor) current() (*qmp.SocketMonitor, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.connected || q.monitor == nil {
+		return nil, ErrMonitorUnavailable
+	}
+	return q.monitor, nil
+}
+
+type runResult struct {
+	raw []byte
+	err error
+}
+
+func (q *QMPMonitor) ExecuteCommandRaw(ctx context.Context, qmpCmd string) ([]byte, error) {
+	m, err := q.current()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, CommandTimeout)
+		defer cancel()
+	}
+	log.Debugf("Execute command %s", qmpCmd)
+	name := commandName(qmpCmd)
+	start := time.Now()
+	res := make(chan runResult, 1)
+	go func() {
+		raw, err := m.Run([]byte(qmpCmd))
+		res <- runResult{raw: raw, err: err}
+	}()
+	var r runResult
+	select {
+	case r = <-res:
+	case <-ctx.Done():
+		// The response can still arrive and it would be read by the next command.
+		// Drop the connection to keep the commands and the responses in sync.
+		q.markBroken(m)
+		r.err = status.FromContextError(ctx.Err()).Err()
+	}
+	qmpCommandDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if r.err != nil {
+		qmpCommandErrors.WithLabelValues(name).Inc()
+		return r.raw, r.err
+	}
+	log.Debugf("Result: %s", string(r.raw))
+	return r.raw, nil
+
+}
+
+var executeRegexp = regexp.MustCompile(`['"]execute['"]\s*:\s*['"]([a-zA-Z0-9_-]+)['"]`)
+
+// commandName extracts the name of the QMP command used to label the metrics
+func commandName(qmpCmd string) string {
+	m := executeRegexp.FindStringSubmatch(qmpCmd)
+	if len(m) < 2 {
+		return "unknown"
+	}
+	return m[1]
+}
+
+func (q *QMPMonitor) ExecuteCommand(ctx context.Context, qmpCmd string) error {
+	raw, err := q.ExecuteCommandRaw(ctx, qmpCmd)
+	if err != nil {
+		return err
+	}
+	var result statusResult
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return fmt.Errorf("failed parsing result %v", err)
+	}
+
+	log.Debugf("Status: %s", result.Return.Status)
+	return nil
+}
+
+// Subscribe returns a channel receiving the events of the monitor. The events are
+// delivered across reconnections until Unsubscribe is called.
+func (q *QMPMonitor) Subscribe() chan qmp.Event {
+	ch := make(chan qmp.Event, 64)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (q *QMPMonitor) Unsubscribe(ch chan qmp.Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.subscribers, ch)
+}
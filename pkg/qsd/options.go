@@ -0,0 +1,88 @@
+package qsd
+
+import (
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sysIoUringSetup is the number of the io_uring_setup syscall, the same on all the architectures
+const sysIoUringSetup = 425
+
+var (
+	aioModes          = map[string]bool{"": true, "threads": true, "native": true, "io_uring": true}
+	detectZeroesModes = map[string]bool{"": true, "off": true, "on": true, "unmap": true}
+
+	ioUringOnce      sync.Once
+	ioUringAvailable bool
+)
+
+// ValidateOptions checks that the options of a volume are valid and consistent
+func ValidateOptions(export *ExportOptions, io *IOOptions) error {
+	if !aioModes[io.GetAio()] {
+		return status.Errorf(codes.InvalidArgument, "Invalid aio mode %q: must be threads, native or io_uring", io.GetAio())
+	}
+	if io.GetAio() == "native" && !io.GetCacheDirect() {
+		return status.Error(codes.InvalidArgument, "The native aio mode requires the direct cache")
+	}
+	if !detectZeroesModes[io.GetDetectZeroes()] {
+		return status.Errorf(codes.InvalidArgument, "Invalid detect-zeroes mode %q: must be off, on or unmap", io.GetDetectZeroes())
+	}
+	if io.GetDetectZeroes() == "unmap" && !export.GetDiscard() {
+		return status.Error(codes.InvalidArgument, "The unmap detect-zeroes mode requires discard")
+	}
+	return nil
+}
+
+// nodeOptions returns the options of the nodes of a volume
+func nodeOptions(export *ExportOptions, io *IOOptions) *NodeOptions {
+	return &NodeOptions{
+		Discard:      export.GetDiscard(),
+		CacheDirect:  io.GetCacheDirect(),
+		CacheNoFlush: io.GetCacheNoFlush(),
+		Aio:          io.GetAio(),
+		DetectZeroes: io.GetDetectZeroes(),
+	}
+}
+
+// checkOptions validates the options of a new volume and verifies that the host supports them
+func checkOptions(export *ExportOptions, io *IOOptions) error {
+	if err := ValidateOptions(export, io); err != nil {
+		return err
+	}
+	if io.GetAio() == "io_uring" && !ioUringSupported() {
+		return status.Error(codes.FailedPrecondition, "io_uring is not supported by the host")
+	}
+	return nil
+}
+
+// ioUringSupported probes if the kernel allows to create an io_uring instance
+func ioUringSupported() bool {
+	ioUringOnce.Do(func() {
+		// struct io_uring_params
+		var params [120]byte
+		fd, _, errno := syscall.Syscall(sysIoUringSetup, 1, uintptr(unsafe.Pointer(&params[0])), 0)
+		if errno != 0 {
+			return
+		}
+		syscall.Close(int(fd))
+		ioUringAvailable = true
+	})
+	return ioUringAvailable
+}
+
+// ioOptions returns the I/O options reported for the node
+func (o *NodeOptions) ioOptions() *IOOptions {
+	if o == nil {
+		return nil
+	}
+	return &IOOptions{
+		CacheDirect:  o.CacheDirect,
+		CacheNoFlush: o.CacheNoFlush,
+		Aio:          o.Aio,
+		DetectZeroes: o.DetectZeroes,
+	}
+}
@@ -0,0 +1,36 @@
+package qsd
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		export *ExportOptions
+		io     *IOOptions
+		code   codes.Code
+	}{
+		{name: "defaults", code: codes.OK},
+		{name: "threads", io: &IOOptions{Aio: "threads"}, code: codes.OK},
+		{name: "io_uring", io: &IOOptions{Aio: "io_uring"}, code: codes.OK},
+		{name: "native with direct cache", io: &IOOptions{Aio: "native", CacheDirect: true}, code: codes.OK},
+		{name: "native without direct cache", io: &IOOptions{Aio: "native"}, code: codes.InvalidArgument},
+		{name: "invalid aio", io: &IOOptions{Aio: "posix"}, code: codes.InvalidArgument},
+		{name: "detect zeroes", io: &IOOptions{DetectZeroes: "on"}, code: codes.OK},
+		{name: "unmap with discard", export: &ExportOptions{Discard: true}, io: &IOOptions{DetectZeroes: "unmap"}, code: codes.OK},
+		{name: "unmap without discard", io: &IOOptions{DetectZeroes: "unmap"}, code: codes.InvalidArgument},
+		{name: "invalid detect zeroes", io: &IOOptions{DetectZeroes: "always"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOptions(tt.export, tt.io)
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("ValidateOptions(%v, %v) code %s, want %s: %v", tt.export, tt.io, code, tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// publishExport is an additional vhost-user export of a volume created for a publish
+type publishExport struct {
+	Socket   string
+	Writable bool
+}
+
+// publishID returns the id of the export for the publish exportID of the node
+func publishID(qsdID, exportID string) string {
+	return fmt.Sprintf("%s-%s", qsdID, exportID)
+}
+
+// PublishSocketDir returns the directory with the socket of the export for the
+// publish exportID of the volume
+func PublishSocketDir(volumeID, exportID string) string {
+	return fmt.Sprintf("%s/%s-%s", socketDir, volumeID, exportID)
+}
+
+// exposePublish creates the export of the volume for a publish. The exports are
+// read-only unless requested otherwise, only one writable export is allowed per
+// volume if the volume isn't shared writable.
+func (c *Server) exposePublish(ctx context.Context, image *Image) (*Response, error) {
+	i, ok := c.images[image.ID]
+	if !ok {
+		errMessage := fmt.Sprintf("Image %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	a, ok := c.images[c.activeLayers[image.ID]]
+	if !ok {
+		errMessage := fmt.Sprintf("Active layer for the volume %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	writable := !image.ReadOnly
+	if p, ok := c.publishes[image.ID][image.ExportID]; ok {
+		if p.Writable != writable {
+			errMessage := fmt.Sprintf("Export %s of the volume %s already exists with a different mode", image.ExportID, image.ID)
+			return failed(errMessage, status.Error(codes.AlreadyExists, errMessage))
+		}
+		return &Response{Success: true}, nil
+	}
+	if writable && !image.SharedWritable {
+		for exportID, p := range c.publishes[image.ID] {
+			if p.Writable {
+				errMessage := fmt.Sprintf("Volume %s is already exported writable by %s", image.ID, exportID)
+				return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+			}
+		}
+	}
+	dir := PublishSocketDir(image.ID, image.ExportID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create socket directory for the volume %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	socket := fmt.Sprintf("%s/%s", dir, vhostSock)
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		errMessage := fmt.Sprintf("Cannot remove the old socket for the volume %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	if err := c.volManager.ExposeVhostUser(ctx, publishID(i.QSDID, image.ExportID), a.QSDID, socket, writable, i.Export); err != nil {
+		os.RemoveAll(dir)
+		errMessage := fmt.Sprintf("Cannot create socket for the volume %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	if c.publishes[image.ID] == nil {
+		c.publishes[image.ID] = make(map[string]*publishExport)
+	}
+	c.publishes[image.ID][image.ExportID] = &publishExport{
+		Socket:   socket,
+		Writable: writable,
+	}
+	log.Infof("Exported volume %s for %s writable:%t", image.ID, image.ExportID, writable)
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// deletePublish removes the export of the volume created for a publish
+func (c *Server) deletePublish(ctx context.Context, image *Image) (*Response, error) {
+	if _, ok := c.snapshotExports[image.ID][image.ExportID]; ok {
+		return c.deleteSnapshotExport(ctx, image)
+	}
+	if _, ok := c.publishes[image.ID][image.ExportID]; !ok {
+		return &Response{Success: true}, nil
+	}
+	i, ok := c.images[image.ID]
+	if !ok {
+		errMessage := fmt.Sprintf("Image %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if err := c.volManager.DeleteExporter(ctx, publishID(i.QSDID, image.ExportID)); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete the export %s for volume %s: %v", image.ExportID, image.ID, err)
+		return failed(errMessage, err)
+	}
+	delete(c.publishes[image.ID], image.ExportID)
+	if len(c.publishes[image.ID]) == 0 {
+		delete(c.publishes, image.ID)
+	}
+	if err := os.RemoveAll(PublishSocketDir(image.ID, image.ExportID)); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete socket directory for volume %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// ExposeSnapshot creates a read-only export of the snapshot for a publish. The
+// content of the snapshot is the backing image of its overlay, the image is already
+// read-only in the chain and neither its references nor the active layer of the
+// volume change. The export is removed by DeleteExporter.
+func (c *Server) ExposeSnapshot(ctx context.Context, image *Image) (*Response, error) {
+	log.Infof("Export snapshot %s for %s", image.ID, image.ExportID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if image.ExportID == "" {
+		errMessage := fmt.Sprintf("Export ID for the snapshot %s must be provided", image.ID)
+		return failed(errMessage, status.Error(codes.InvalidArgument, errMessage))
+	}
+	if _, ok := c.snapshotExports[image.ID][image.ExportID]; ok {
+		return &Response{Success: true}, nil
+	}
+	if _, ok := c.internalSnapshots[image.ID]; ok {
+		errMessage := fmt.Sprintf("Exporting the internal snapshot %s is not supported", image.ID)
+		return failed(errMessage, status.Error(codes.Unimplemented, errMessage))
+	}
+	s, ok := c.images[image.ID]
+	if !ok || s.VolumeRef != image.ID {
+		errMessage := fmt.Sprintf("Snapshot %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	b, ok := c.images[s.BackingImageID]
+	if !ok {
+		errMessage := fmt.Sprintf("Backing image of the snapshot %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	dir := PublishSocketDir(image.ID, image.ExportID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create socket directory for the snapshot %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	socket := fmt.Sprintf("%s/%s", dir, vhostSock)
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		errMessage := fmt.Sprintf("Cannot remove the old socket for the snapshot %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	if err := c.volManager.ExposeVhostUser(ctx, publishID(s.QSDID, image.ExportID), b.QSDID, socket, false, c.snapshotExportOptions(s)); err != nil {
+		os.RemoveAll(dir)
+		errMessage := fmt.Sprintf("Cannot create socket for the snapshot %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	if c.snapshotExports[image.ID] == nil {
+		c.snapshotExports[image.ID] = make(map[string]*publishExport)
+	}
+	c.snapshotExports[image.ID][image.ExportID] = &publishExport{Socket: socket}
+	log.Infof("Exported snapshot %s for %s", image.ID, image.ExportID)
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// deleteSnapshotExport removes the export of the snapshot created for a publish
+func (c *Server) deleteSnapshotExport(ctx context.Context, image *Image) (*Response, error) {
+	s, ok := c.images[image.ID]
+	if !ok {
+		errMessage := fmt.Sprintf("Snapshot %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if err := c.volManager.DeleteExporter(ctx, publishID(s.QSDID, image.ExportID)); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete the export %s for snapshot %s: %v", image.ExportID, image.ID, err)
+		return failed(errMessage, err)
+	}
+	delete(c.snapshotExports[image.ID], image.ExportID)
+	if len(c.snapshotExports[image.ID]) == 0 {
+		delete(c.snapshotExports, image.ID)
+	}
+	if err := os.RemoveAll(PublishSocketDir(image.ID, image.ExportID)); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete socket directory for snapshot %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// snapshotExportOptions returns the export options of the volume of the snapshot,
+// the guest sees the same disk geometry
+func (c *Server) snapshotExportOptions(s *QCOWImage) *ExportOptions {
+	if v, ok := c.images[filepath.Base(filepath.Dir(s.File))]; ok {
+		return v.Export
+	}
+	return nil
+}
@@ -25,14 +25,71 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type ResponseHealth_Status int32
+
+const (
+	ResponseHealth_UNKNOWN  ResponseHealth_Status = 0
+	ResponseHealth_SERVING  ResponseHealth_Status = 1
+	ResponseHealth_DEGRADED ResponseHealth_Status = 2
+)
+
+// Enum value maps for ResponseHealth_Status.
+var (
+	ResponseHealth_Status_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SERVING",
+		2: "DEGRADED",
+	}
+	ResponseHealth_Status_value = map[string]int32{
+		"UNKNOWN":  0,
+		"SERVING":  1,
+		"DEGRADED": 2,
+	}
+)
+
+func (x ResponseHealth_Status) Enum() *ResponseHealth_Status {
+	p := new(ResponseHealth_Status)
+	*p = x
+	return p
+}
+
+func (x ResponseHealth_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResponseHealth_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_qsd_qsd_proto_enumTypes[0].Descriptor()
+}
+
+func (ResponseHealth_Status) Type() protoreflect.EnumType {
+	return &file_pkg_qsd_qsd_proto_enumTypes[0]
+}
+
+func (x ResponseHealth_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResponseHealth_Status.Descriptor instead.
+func (ResponseHealth_Status) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{21, 0}
+}
+
 type Image struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID         string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Size       int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	FromVolume string `protobuf:"bytes,3,opt,name=FromVolume,proto3" json:"FromVolume,omitempty"`
+	ID           string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Size         int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	FromVolume   string `protobuf:"bytes,3,opt,name=FromVolume,proto3" json:"FromVolume,omitempty"`
+	PVCName      string `protobuf:"bytes,4,opt,name=PVCName,proto3" json:"PVCName,omitempty"`
+	PVCNamespace string `protobuf:"bytes,5,opt,name=PVCNamespace,proto3" json:"PVCNamespace,omitempty"`
+	// ExportID identifies an additional export created for a publish of the volume
+	ExportID       string         `protobuf:"bytes,6,opt,name=ExportID,proto3" json:"ExportID,omitempty"`
+	ReadOnly       bool           `protobuf:"varint,7,opt,name=ReadOnly,proto3" json:"ReadOnly,omitempty"`
+	SharedWritable bool           `protobuf:"varint,8,opt,name=SharedWritable,proto3" json:"SharedWritable,omitempty"`
+	Export         *ExportOptions `protobuf:"bytes,9,opt,name=Export,proto3" json:"Export,omitempty"`
+	IO             *IOOptions     `protobuf:"bytes,10,opt,name=IO,proto3" json:"IO,omitempty"`
 }
 
 func (x *Image) Reset() {
@@ -88,17 +145,73 @@ func (x *Image) GetFromVolume() string {
 	return ""
 }
 
-type Snapshot struct {
+func (x *Image) GetPVCName() string {
+	if x != nil {
+		return x.PVCName
+	}
+	return ""
+}
+
+func (x *Image) GetPVCNamespace() string {
+	if x != nil {
+		return x.PVCNamespace
+	}
+	return ""
+}
+
+func (x *Image) GetExportID() string {
+	if x != nil {
+		return x.ExportID
+	}
+	return ""
+}
+
+func (x *Image) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *Image) GetSharedWritable() bool {
+	if x != nil {
+		return x.SharedWritable
+	}
+	return false
+}
+
+func (x *Image) GetExport() *ExportOptions {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *Image) GetIO() *IOOptions {
+	if x != nil {
+		return x.IO
+	}
+	return nil
+}
+
+// IOOptions configure how the nodes of a volume access the image files
+type IOOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ID             string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	SourceVolumeID string `protobuf:"bytes,2,opt,name=SourceVolumeID,proto3" json:"SourceVolumeID,omitempty"`
+	// CacheDirect bypasses the page cache of the host
+	CacheDirect bool `protobuf:"varint,1,opt,name=CacheDirect,proto3" json:"CacheDirect,omitempty"`
+	// CacheNoFlush ignores the flush requests
+	CacheNoFlush bool `protobuf:"varint,2,opt,name=CacheNoFlush,proto3" json:"CacheNoFlush,omitempty"`
+	// Aio is the AIO mode of the files: threads, native or io_uring
+	Aio string `protobuf:"bytes,3,opt,name=Aio,proto3" json:"Aio,omitempty"`
+	// DetectZeroes is off, on or unmap
+	DetectZeroes string `protobuf:"bytes,4,opt,name=DetectZeroes,proto3" json:"DetectZeroes,omitempty"`
 }
 
-func (x *Snapshot) Reset() {
-	*x = Snapshot{}
+func (x *IOOptions) Reset() {
+	*x = IOOptions{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_qsd_qsd_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -106,13 +219,13 @@ func (x *Snapshot) Reset() {
 	}
 }
 
-func (x *Snapshot) String() string {
+func (x *IOOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Snapshot) ProtoMessage() {}
+func (*IOOptions) ProtoMessage() {}
 
-func (x *Snapshot) ProtoReflect() protoreflect.Message {
+func (x *IOOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_qsd_qsd_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -124,33 +237,57 @@ func (x *Snapshot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
-func (*Snapshot) Descriptor() ([]byte, []int) {
+// Deprecated: Use IOOptions.ProtoReflect.Descriptor instead.
+func (*IOOptions) Descriptor() ([]byte, []int) {
 	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Snapshot) GetID() string {
+func (x *IOOptions) GetCacheDirect() bool {
 	if x != nil {
-		return x.ID
+		return x.CacheDirect
+	}
+	return false
+}
+
+func (x *IOOptions) GetCacheNoFlush() bool {
+	if x != nil {
+		return x.CacheNoFlush
+	}
+	return false
+}
+
+func (x *IOOptions) GetAio() string {
+	if x != nil {
+		return x.Aio
 	}
 	return ""
 }
 
-func (x *Snapshot) GetSourceVolumeID() string {
+func (x *IOOptions) GetDetectZeroes() string {
 	if x != nil {
-		return x.SourceVolumeID
+		return x.DetectZeroes
 	}
 	return ""
 }
 
-type ListVolumesParams struct {
+// ExportOptions configure the vhost-user-blk exports of a volume
+type ExportOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// NumQueues is the number of virtqueues, the default is 1
+	NumQueues uint32 `protobuf:"varint,1,opt,name=NumQueues,proto3" json:"NumQueues,omitempty"`
+	// LogicalBlockSize in bytes, the default is 512
+	LogicalBlockSize uint32 `protobuf:"varint,2,opt,name=LogicalBlockSize,proto3" json:"LogicalBlockSize,omitempty"`
+	// Writethrough flushes the writes before completing them
+	Writethrough bool `protobuf:"varint,3,opt,name=Writethrough,proto3" json:"Writethrough,omitempty"`
+	// Discard passes the discard and write-zeroes requests to the image file
+	Discard bool `protobuf:"varint,4,opt,name=Discard,proto3" json:"Discard,omitempty"`
 }
 
-func (x *ListVolumesParams) Reset() {
-	*x = ListVolumesParams{}
+func (x *ExportOptions) Reset() {
+	*x = ExportOptions{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_qsd_qsd_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -158,13 +295,13 @@ func (x *ListVolumesParams) Reset() {
 	}
 }
 
-func (x *ListVolumesParams) String() string {
+func (x *ExportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVolumesParams) ProtoMessage() {}
+func (*ExportOptions) ProtoMessage() {}
 
-func (x *ListVolumesParams) ProtoReflect() protoreflect.Message {
+func (x *ExportOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_qsd_qsd_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -176,22 +313,53 @@ func (x *ListVolumesParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVolumesParams.ProtoReflect.Descriptor instead.
-func (*ListVolumesParams) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportOptions.ProtoReflect.Descriptor instead.
+func (*ExportOptions) Descriptor() ([]byte, []int) {
 	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{2}
 }
 
-type Response struct {
+func (x *ExportOptions) GetNumQueues() uint32 {
+	if x != nil {
+		return x.NumQueues
+	}
+	return 0
+}
+
+func (x *ExportOptions) GetLogicalBlockSize() uint32 {
+	if x != nil {
+		return x.LogicalBlockSize
+	}
+	return 0
+}
+
+func (x *ExportOptions) GetWritethrough() bool {
+	if x != nil {
+		return x.Writethrough
+	}
+	return false
+}
+
+func (x *ExportOptions) GetDiscard() bool {
+	if x != nil {
+		return x.Discard
+	}
+	return false
+}
+
+type Snapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ID             string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	SourceVolumeID string `protobuf:"bytes,2,opt,name=SourceVolumeID,proto3" json:"SourceVolumeID,omitempty"`
+	// Internal stores the snapshot in the qcow2 image of the active layer instead
+	// of adding an overlay
+	Internal bool `protobuf:"varint,3,opt,name=Internal,proto3" json:"Internal,omitempty"`
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_qsd_qsd_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -199,13 +367,13 @@ func (x *Response) Reset() {
 	}
 }
 
-func (x *Response) String() string {
+func (x *Snapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*Snapshot) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_qsd_qsd_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -217,35 +385,44 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
 	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Response) GetSuccess() bool {
+func (x *Snapshot) GetID() string {
 	if x != nil {
-		return x.Success
+		return x.ID
 	}
-	return false
+	return ""
 }
 
-func (x *Response) GetMessage() string {
+func (x *Snapshot) GetSourceVolumeID() string {
 	if x != nil {
-		return x.Message
+		return x.SourceVolumeID
 	}
 	return ""
 }
 
-type ResponseListVolumes struct {
+func (x *Snapshot) GetInternal() bool {
+	if x != nil {
+		return x.Internal
+	}
+	return false
+}
+
+// GroupSnapshot snapshots together volumes on the same node
+type GroupSnapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Volumes []*Volume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	ID        string      `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Snapshots []*Snapshot `protobuf:"bytes,2,rep,name=Snapshots,proto3" json:"Snapshots,omitempty"`
 }
 
-func (x *ResponseListVolumes) Reset() {
-	*x = ResponseListVolumes{}
+func (x *GroupSnapshot) Reset() {
+	*x = GroupSnapshot{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_qsd_qsd_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -253,13 +430,13 @@ func (x *ResponseListVolumes) Reset() {
 	}
 }
 
-func (x *ResponseListVolumes) String() string {
+func (x *GroupSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseListVolumes) ProtoMessage() {}
+func (*GroupSnapshot) ProtoMessage() {}
 
-func (x *ResponseListVolumes) ProtoReflect() protoreflect.Message {
+func (x *GroupSnapshot) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_qsd_qsd_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -271,33 +448,37 @@ func (x *ResponseListVolumes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseListVolumes.ProtoReflect.Descriptor instead.
-func (*ResponseListVolumes) Descriptor() ([]byte, []int) {
+// Deprecated: Use GroupSnapshot.ProtoReflect.Descriptor instead.
+func (*GroupSnapshot) Descriptor() ([]byte, []int) {
 	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ResponseListVolumes) GetVolumes() []*Volume {
+func (x *GroupSnapshot) GetID() string {
 	if x != nil {
-		return x.Volumes
+		return x.ID
+	}
+	return ""
+}
+
+func (x *GroupSnapshot) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
 	}
 	return nil
 }
 
-type Volume struct {
+// RevertParams reverts the volume in place to one of its snapshots
+type RevertParams struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QSDID          string `protobuf:"bytes,1,opt,name=QSDID,proto3" json:"QSDID,omitempty"`
-	BackingImageID string `protobuf:"bytes,2,opt,name=BackingImageID,proto3" json:"BackingImageID,omitempty"`
-	File           string `protobuf:"bytes,3,opt,name=File,proto3" json:"File,omitempty"`
-	RefCount       int32  `protobuf:"varint,4,opt,name=RefCount,proto3" json:"RefCount,omitempty"`
-	VolumeRef      string `protobuf:"bytes,5,opt,name=VolumeRef,proto3" json:"VolumeRef,omitempty"`
-	Depth          uint32 `protobuf:"varint,6,opt,name=Depth,proto3" json:"Depth,omitempty"`
+	VolumeID   string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	SnapshotID string `protobuf:"bytes,2,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
 }
 
-func (x *Volume) Reset() {
-	*x = Volume{}
+func (x *RevertParams) Reset() {
+	*x = RevertParams{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_qsd_qsd_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -305,13 +486,13 @@ func (x *Volume) Reset() {
 	}
 }
 
-func (x *Volume) String() string {
+func (x *RevertParams) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Volume) ProtoMessage() {}
+func (*RevertParams) ProtoMessage() {}
 
-func (x *Volume) ProtoReflect() protoreflect.Message {
+func (x *RevertParams) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_qsd_qsd_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -323,173 +504,2073 @@ func (x *Volume) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
-func (*Volume) Descriptor() ([]byte, []int) {
+// Deprecated: Use RevertParams.ProtoReflect.Descriptor instead.
+func (*RevertParams) Descriptor() ([]byte, []int) {
 	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Volume) GetQSDID() string {
+func (x *RevertParams) GetVolumeID() string {
 	if x != nil {
-		return x.QSDID
+		return x.VolumeID
 	}
 	return ""
 }
 
-func (x *Volume) GetBackingImageID() string {
+func (x *RevertParams) GetSnapshotID() string {
 	if x != nil {
-		return x.BackingImageID
+		return x.SnapshotID
 	}
 	return ""
 }
 
-func (x *Volume) GetFile() string {
+// SnapshotMetadataParams requests the extents allocated in the snapshot or, when
+// BaseSnapshotID is set, the extents changed since the base snapshot
+type SnapshotMetadataParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SnapshotID     string `protobuf:"bytes,1,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	BaseSnapshotID string `protobuf:"bytes,2,opt,name=BaseSnapshotID,proto3" json:"BaseSnapshotID,omitempty"`
+	StartingOffset int64  `protobuf:"varint,3,opt,name=StartingOffset,proto3" json:"StartingOffset,omitempty"`
+	MaxResults     int32  `protobuf:"varint,4,opt,name=MaxResults,proto3" json:"MaxResults,omitempty"`
+}
+
+func (x *SnapshotMetadataParams) Reset() {
+	*x = SnapshotMetadataParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotMetadataParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotMetadataParams) ProtoMessage() {}
+
+func (x *SnapshotMetadataParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotMetadataParams.ProtoReflect.Descriptor instead.
+func (*SnapshotMetadataParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SnapshotMetadataParams) GetSnapshotID() string {
 	if x != nil {
-		return x.File
+		return x.SnapshotID
 	}
 	return ""
 }
 
-func (x *Volume) GetRefCount() int32 {
+func (x *SnapshotMetadataParams) GetBaseSnapshotID() string {
 	if x != nil {
-		return x.RefCount
+		return x.BaseSnapshotID
 	}
-	return 0
+	return ""
 }
 
-func (x *Volume) GetVolumeRef() string {
+func (x *SnapshotMetadataParams) GetStartingOffset() int64 {
 	if x != nil {
-		return x.VolumeRef
+		return x.StartingOffset
 	}
-	return ""
+	return 0
 }
 
-func (x *Volume) GetDepth() uint32 {
+func (x *SnapshotMetadataParams) GetMaxResults() int32 {
 	if x != nil {
-		return x.Depth
+		return x.MaxResults
 	}
 	return 0
 }
 
-var File_pkg_qsd_qsd_proto protoreflect.FileDescriptor
+type BlockExtent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_pkg_qsd_qsd_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x73, 0x64, 0x2f, 0x71, 0x73, 0x64, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
-	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x22, 0x4b, 0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
-	0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22, 0x42, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49,
-	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
-	0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73,
-	0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x3e,
-	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4c,
-	0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f,
-	0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72,
-	0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x22, 0xaa, 0x01, 0x0a,
-	0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x53, 0x44, 0x49, 0x44, 0x12, 0x26, 0x0a,
-	0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x66,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x66,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52,
-	0x65, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
-	0x52, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x32, 0xcc, 0x04, 0x0a, 0x0a, 0x51, 0x73,
-	0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65,
-	0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
-	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x56,
-	0x68, 0x6f, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65,
-	0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
-	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
-	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
-	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x70, 0x6f,
-	0x72, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
-	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
-	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x50, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73,
-	0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73,
-	0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
-	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
-	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
-	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
-	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x28, 0x2e, 0x61,
-	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
-	0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2f, 0x63,
-	0x73, 0x69, 0x2d, 0x71, 0x73, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x73, 0x64, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Offset int64 `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Length int64 `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
 }
 
-var (
-	file_pkg_qsd_qsd_proto_rawDescOnce sync.Once
-	file_pkg_qsd_qsd_proto_rawDescData = file_pkg_qsd_qsd_proto_rawDesc
-)
+func (x *BlockExtent) Reset() {
+	*x = BlockExtent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_pkg_qsd_qsd_proto_rawDescGZIP() []byte {
-	file_pkg_qsd_qsd_proto_rawDescOnce.Do(func() {
-		file_pkg_qsd_qsd_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_qsd_qsd_proto_rawDescData)
-	})
-	return file_pkg_qsd_qsd_proto_rawDescData
+func (x *BlockExtent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_pkg_qsd_qsd_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_pkg_qsd_qsd_proto_goTypes = []interface{}{
-	(*Image)(nil),               // 0: alicefr.csi.pkg.qsd.Image
-	(*Snapshot)(nil),            // 1: alicefr.csi.pkg.qsd.Snapshot
-	(*ListVolumesParams)(nil),   // 2: alicefr.csi.pkg.qsd.ListVolumesParams
-	(*Response)(nil),            // 3: alicefr.csi.pkg.qsd.Response
-	(*ResponseListVolumes)(nil), // 4: alicefr.csi.pkg.qsd.ResponseListVolumes
-	(*Volume)(nil),              // 5: alicefr.csi.pkg.qsd.Volume
+func (*BlockExtent) ProtoMessage() {}
+
+func (x *BlockExtent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_pkg_qsd_qsd_proto_depIdxs = []int32{
-	5, // 0: alicefr.csi.pkg.qsd.ResponseListVolumes.volumes:type_name -> alicefr.csi.pkg.qsd.Volume
-	0, // 1: alicefr.csi.pkg.qsd.QsdService.CreateVolume:input_type -> alicefr.csi.pkg.qsd.Image
-	0, // 2: alicefr.csi.pkg.qsd.QsdService.ExposeVhostUser:input_type -> alicefr.csi.pkg.qsd.Image
-	0, // 3: alicefr.csi.pkg.qsd.QsdService.DeleteVolume:input_type -> alicefr.csi.pkg.qsd.Image
-	0, // 4: alicefr.csi.pkg.qsd.QsdService.DeleteExporter:input_type -> alicefr.csi.pkg.qsd.Image
-	1, // 5: alicefr.csi.pkg.qsd.QsdService.CreateSnapshot:input_type -> alicefr.csi.pkg.qsd.Snapshot
-	1, // 6: alicefr.csi.pkg.qsd.QsdService.DeleteSnapshot:input_type -> alicefr.csi.pkg.qsd.Snapshot
-	2, // 7: alicefr.csi.pkg.qsd.QsdService.ListVolumes:input_type -> alicefr.csi.pkg.qsd.ListVolumesParams
-	3, // 8: alicefr.csi.pkg.qsd.QsdService.CreateVolume:output_type -> alicefr.csi.pkg.qsd.Response
-	3, // 9: alicefr.csi.pkg.qsd.QsdService.ExposeVhostUser:output_type -> alicefr.csi.pkg.qsd.Response
-	3, // 10: alicefr.csi.pkg.qsd.QsdService.DeleteVolume:output_type -> alicefr.csi.pkg.qsd.Response
-	3, // 11: alicefr.csi.pkg.qsd.QsdService.DeleteExporter:output_type -> alicefr.csi.pkg.qsd.Response
-	3, // 12: alicefr.csi.pkg.qsd.QsdService.CreateSnapshot:output_type -> alicefr.csi.pkg.qsd.Response
-	3, // 13: alicefr.csi.pkg.qsd.QsdService.DeleteSnapshot:output_type -> alicefr.csi.pkg.qsd.Response
-	4, // 14: alicefr.csi.pkg.qsd.QsdService.ListVolumes:output_type -> alicefr.csi.pkg.qsd.ResponseListVolumes
-	8, // [8:15] is the sub-list for method output_type
-	1, // [1:8] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use BlockExtent.ProtoReflect.Descriptor instead.
+func (*BlockExtent) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BlockExtent) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *BlockExtent) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type BlockMetadataPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeCapacity int64          `protobuf:"varint,1,opt,name=VolumeCapacity,proto3" json:"VolumeCapacity,omitempty"`
+	Extents        []*BlockExtent `protobuf:"bytes,2,rep,name=Extents,proto3" json:"Extents,omitempty"`
+}
+
+func (x *BlockMetadataPage) Reset() {
+	*x = BlockMetadataPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockMetadataPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockMetadataPage) ProtoMessage() {}
+
+func (x *BlockMetadataPage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockMetadataPage.ProtoReflect.Descriptor instead.
+func (*BlockMetadataPage) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockMetadataPage) GetVolumeCapacity() int64 {
+	if x != nil {
+		return x.VolumeCapacity
+	}
+	return 0
+}
+
+func (x *BlockMetadataPage) GetExtents() []*BlockExtent {
+	if x != nil {
+		return x.Extents
+	}
+	return nil
+}
+
+type BackupParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeID    string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	BackupID    string `protobuf:"bytes,2,opt,name=BackupID,proto3" json:"BackupID,omitempty"`
+	Incremental bool   `protobuf:"varint,3,opt,name=Incremental,proto3" json:"Incremental,omitempty"`
+}
+
+func (x *BackupParams) Reset() {
+	*x = BackupParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupParams) ProtoMessage() {}
+
+func (x *BackupParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupParams.ProtoReflect.Descriptor instead.
+func (*BackupParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BackupParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *BackupParams) GetBackupID() string {
+	if x != nil {
+		return x.BackupID
+	}
+	return ""
+}
+
+func (x *BackupParams) GetIncremental() bool {
+	if x != nil {
+		return x.Incremental
+	}
+	return false
+}
+
+type ResponseBackup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success  bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message  string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BackupID string `protobuf:"bytes,3,opt,name=BackupID,proto3" json:"BackupID,omitempty"`
+	// Parent is empty for the full backups
+	Parent      string `protobuf:"bytes,4,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	VirtualSize int64  `protobuf:"varint,5,opt,name=VirtualSize,proto3" json:"VirtualSize,omitempty"`
+}
+
+func (x *ResponseBackup) Reset() {
+	*x = ResponseBackup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseBackup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseBackup) ProtoMessage() {}
+
+func (x *ResponseBackup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseBackup.ProtoReflect.Descriptor instead.
+func (*ResponseBackup) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResponseBackup) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResponseBackup) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseBackup) GetBackupID() string {
+	if x != nil {
+		return x.BackupID
+	}
+	return ""
+}
+
+func (x *ResponseBackup) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ResponseBackup) GetVirtualSize() int64 {
+	if x != nil {
+		return x.VirtualSize
+	}
+	return 0
+}
+
+type RestoreParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// VolumeID is the new volume created from the backup
+	VolumeID       string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	BackupID       string `protobuf:"bytes,2,opt,name=BackupID,proto3" json:"BackupID,omitempty"`
+	SourceVolumeID string `protobuf:"bytes,3,opt,name=SourceVolumeID,proto3" json:"SourceVolumeID,omitempty"`
+}
+
+func (x *RestoreParams) Reset() {
+	*x = RestoreParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreParams) ProtoMessage() {}
+
+func (x *RestoreParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreParams.ProtoReflect.Descriptor instead.
+func (*RestoreParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *RestoreParams) GetBackupID() string {
+	if x != nil {
+		return x.BackupID
+	}
+	return ""
+}
+
+func (x *RestoreParams) GetSourceVolumeID() string {
+	if x != nil {
+		return x.SourceVolumeID
+	}
+	return ""
+}
+
+type ResponseNBDExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success    bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Port       string `protobuf:"bytes,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	ExportName string `protobuf:"bytes,4,opt,name=ExportName,proto3" json:"ExportName,omitempty"`
+	Size       int64  `protobuf:"varint,5,opt,name=Size,proto3" json:"Size,omitempty"`
+	TLS        bool   `protobuf:"varint,6,opt,name=TLS,proto3" json:"TLS,omitempty"`
+}
+
+func (x *ResponseNBDExport) Reset() {
+	*x = ResponseNBDExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseNBDExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseNBDExport) ProtoMessage() {}
+
+func (x *ResponseNBDExport) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseNBDExport.ProtoReflect.Descriptor instead.
+func (*ResponseNBDExport) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResponseNBDExport) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResponseNBDExport) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseNBDExport) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *ResponseNBDExport) GetExportName() string {
+	if x != nil {
+		return x.ExportName
+	}
+	return ""
+}
+
+func (x *ResponseNBDExport) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ResponseNBDExport) GetTLS() bool {
+	if x != nil {
+		return x.TLS
+	}
+	return false
+}
+
+type MigrateParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// VolumeID is the volume created on the destination
+	VolumeID string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	// Host and Port of the NBD server of the source
+	Host         string         `protobuf:"bytes,2,opt,name=Host,proto3" json:"Host,omitempty"`
+	Port         string         `protobuf:"bytes,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	ExportName   string         `protobuf:"bytes,4,opt,name=ExportName,proto3" json:"ExportName,omitempty"`
+	Size         int64          `protobuf:"varint,5,opt,name=Size,proto3" json:"Size,omitempty"`
+	TLS          bool           `protobuf:"varint,6,opt,name=TLS,proto3" json:"TLS,omitempty"`
+	PVCName      string         `protobuf:"bytes,7,opt,name=PVCName,proto3" json:"PVCName,omitempty"`
+	PVCNamespace string         `protobuf:"bytes,8,opt,name=PVCNamespace,proto3" json:"PVCNamespace,omitempty"`
+	Export       *ExportOptions `protobuf:"bytes,9,opt,name=Export,proto3" json:"Export,omitempty"`
+	IO           *IOOptions     `protobuf:"bytes,10,opt,name=IO,proto3" json:"IO,omitempty"`
+}
+
+func (x *MigrateParams) Reset() {
+	*x = MigrateParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigrateParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateParams) ProtoMessage() {}
+
+func (x *MigrateParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateParams.ProtoReflect.Descriptor instead.
+func (*MigrateParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MigrateParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetExportName() string {
+	if x != nil {
+		return x.ExportName
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MigrateParams) GetTLS() bool {
+	if x != nil {
+		return x.TLS
+	}
+	return false
+}
+
+func (x *MigrateParams) GetPVCName() string {
+	if x != nil {
+		return x.PVCName
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetPVCNamespace() string {
+	if x != nil {
+		return x.PVCNamespace
+	}
+	return ""
+}
+
+func (x *MigrateParams) GetExport() *ExportOptions {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *MigrateParams) GetIO() *IOOptions {
+	if x != nil {
+		return x.IO
+	}
+	return nil
+}
+
+// ReplicaParams describes the replica of a volume created on the peer node
+type ReplicaParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeID     string         `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	Size         int64          `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	PVCName      string         `protobuf:"bytes,3,opt,name=PVCName,proto3" json:"PVCName,omitempty"`
+	PVCNamespace string         `protobuf:"bytes,4,opt,name=PVCNamespace,proto3" json:"PVCNamespace,omitempty"`
+	Export       *ExportOptions `protobuf:"bytes,5,opt,name=Export,proto3" json:"Export,omitempty"`
+	IO           *IOOptions     `protobuf:"bytes,6,opt,name=IO,proto3" json:"IO,omitempty"`
+}
+
+func (x *ReplicaParams) Reset() {
+	*x = ReplicaParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicaParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaParams) ProtoMessage() {}
+
+func (x *ReplicaParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaParams.ProtoReflect.Descriptor instead.
+func (*ReplicaParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReplicaParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *ReplicaParams) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ReplicaParams) GetPVCName() string {
+	if x != nil {
+		return x.PVCName
+	}
+	return ""
+}
+
+func (x *ReplicaParams) GetPVCNamespace() string {
+	if x != nil {
+		return x.PVCNamespace
+	}
+	return ""
+}
+
+func (x *ReplicaParams) GetExport() *ExportOptions {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *ReplicaParams) GetIO() *IOOptions {
+	if x != nil {
+		return x.IO
+	}
+	return nil
+}
+
+// ReplicationParams connects the volume on the primary to the NBD export of its replica
+type ReplicationParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeID   string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	Host       string `protobuf:"bytes,2,opt,name=Host,proto3" json:"Host,omitempty"`
+	Port       string `protobuf:"bytes,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	ExportName string `protobuf:"bytes,4,opt,name=ExportName,proto3" json:"ExportName,omitempty"`
+	TLS        bool   `protobuf:"varint,5,opt,name=TLS,proto3" json:"TLS,omitempty"`
+	// Async copies only the clusters written since the previous sync every RPO seconds
+	Async bool  `protobuf:"varint,6,opt,name=Async,proto3" json:"Async,omitempty"`
+	RPO   int64 `protobuf:"varint,7,opt,name=RPO,proto3" json:"RPO,omitempty"`
+}
+
+func (x *ReplicationParams) Reset() {
+	*x = ReplicationParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationParams) ProtoMessage() {}
+
+func (x *ReplicationParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationParams.ProtoReflect.Descriptor instead.
+func (*ReplicationParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReplicationParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *ReplicationParams) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ReplicationParams) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+func (x *ReplicationParams) GetExportName() string {
+	if x != nil {
+		return x.ExportName
+	}
+	return ""
+}
+
+func (x *ReplicationParams) GetTLS() bool {
+	if x != nil {
+		return x.TLS
+	}
+	return false
+}
+
+func (x *ReplicationParams) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *ReplicationParams) GetRPO() int64 {
+	if x != nil {
+		return x.RPO
+	}
+	return 0
+}
+
+type ImportParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeID string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	// Source is a http(s) URL or a local path in the import directory of the node
+	Source string `protobuf:"bytes,2,opt,name=Source,proto3" json:"Source,omitempty"`
+	// Format of the source, detected by qemu-img if empty
+	Format       string         `protobuf:"bytes,3,opt,name=Format,proto3" json:"Format,omitempty"`
+	PVCName      string         `protobuf:"bytes,4,opt,name=PVCName,proto3" json:"PVCName,omitempty"`
+	PVCNamespace string         `protobuf:"bytes,5,opt,name=PVCNamespace,proto3" json:"PVCNamespace,omitempty"`
+	Export       *ExportOptions `protobuf:"bytes,6,opt,name=Export,proto3" json:"Export,omitempty"`
+	IO           *IOOptions     `protobuf:"bytes,7,opt,name=IO,proto3" json:"IO,omitempty"`
+}
+
+func (x *ImportParams) Reset() {
+	*x = ImportParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportParams) ProtoMessage() {}
+
+func (x *ImportParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportParams.ProtoReflect.Descriptor instead.
+func (*ImportParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *ImportParams) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportParams) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportParams) GetPVCName() string {
+	if x != nil {
+		return x.PVCName
+	}
+	return ""
+}
+
+func (x *ImportParams) GetPVCNamespace() string {
+	if x != nil {
+		return x.PVCNamespace
+	}
+	return ""
+}
+
+func (x *ImportParams) GetExport() *ExportOptions {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *ImportParams) GetIO() *IOOptions {
+	if x != nil {
+		return x.IO
+	}
+	return nil
+}
+
+type ExportParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeID string `protobuf:"bytes,1,opt,name=VolumeID,proto3" json:"VolumeID,omitempty"`
+	// Destination is a local path in the export directory of the node
+	Destination string `protobuf:"bytes,2,opt,name=Destination,proto3" json:"Destination,omitempty"`
+	// Format of the destination: qcow2, raw, vmdk or vhdx
+	Format string `protobuf:"bytes,3,opt,name=Format,proto3" json:"Format,omitempty"`
+}
+
+func (x *ExportParams) Reset() {
+	*x = ExportParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportParams) ProtoMessage() {}
+
+func (x *ExportParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportParams.ProtoReflect.Descriptor instead.
+func (*ExportParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExportParams) GetVolumeID() string {
+	if x != nil {
+		return x.VolumeID
+	}
+	return ""
+}
+
+func (x *ExportParams) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *ExportParams) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type Progress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Percent float32 `protobuf:"fixed32,1,opt,name=Percent,proto3" json:"Percent,omitempty"`
+	Done    bool    `protobuf:"varint,2,opt,name=Done,proto3" json:"Done,omitempty"`
+	Message string  `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Progress) GetPercent() float32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *Progress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *Progress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListVolumesParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListVolumesParams) Reset() {
+	*x = ListVolumesParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVolumesParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesParams) ProtoMessage() {}
+
+func (x *ListVolumesParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesParams.ProtoReflect.Descriptor instead.
+func (*ListVolumesParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{19}
+}
+
+type HealthParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HealthParams) Reset() {
+	*x = HealthParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthParams) ProtoMessage() {}
+
+func (x *HealthParams) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthParams.ProtoReflect.Descriptor instead.
+func (*HealthParams) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{20}
+}
+
+type ResponseHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   ResponseHealth_Status `protobuf:"varint,1,opt,name=status,proto3,enum=alicefr.csi.pkg.qsd.ResponseHealth_Status" json:"status,omitempty"`
+	Message  string                `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Restarts int32                 `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
+}
+
+func (x *ResponseHealth) Reset() {
+	*x = ResponseHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseHealth) ProtoMessage() {}
+
+func (x *ResponseHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseHealth.ProtoReflect.Descriptor instead.
+func (*ResponseHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResponseHealth) GetStatus() ResponseHealth_Status {
+	if x != nil {
+		return x.Status
+	}
+	return ResponseHealth_UNKNOWN
+}
+
+func (x *ResponseHealth) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseHealth) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Response) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Response) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ResponseSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// CreationTime is the creation time of the snapshot in nanoseconds since the epoch
+	CreationTime int64 `protobuf:"varint,3,opt,name=CreationTime,proto3" json:"CreationTime,omitempty"`
+	// RestoreSize is the size of a volume restored from the snapshot
+	RestoreSize int64 `protobuf:"varint,4,opt,name=RestoreSize,proto3" json:"RestoreSize,omitempty"`
+	// AllocatedBytes are allocated by the data written since the previous snapshot
+	AllocatedBytes int64 `protobuf:"varint,5,opt,name=AllocatedBytes,proto3" json:"AllocatedBytes,omitempty"`
+}
+
+func (x *ResponseSnapshot) Reset() {
+	*x = ResponseSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSnapshot) ProtoMessage() {}
+
+func (x *ResponseSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSnapshot.ProtoReflect.Descriptor instead.
+func (*ResponseSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResponseSnapshot) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResponseSnapshot) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseSnapshot) GetCreationTime() int64 {
+	if x != nil {
+		return x.CreationTime
+	}
+	return 0
+}
+
+func (x *ResponseSnapshot) GetRestoreSize() int64 {
+	if x != nil {
+		return x.RestoreSize
+	}
+	return 0
+}
+
+func (x *ResponseSnapshot) GetAllocatedBytes() int64 {
+	if x != nil {
+		return x.AllocatedBytes
+	}
+	return 0
+}
+
+// ResponseGroupSnapshot contains the snapshots in the order of the request
+type ResponseGroupSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success   bool                `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message   string              `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Snapshots []*ResponseSnapshot `protobuf:"bytes,3,rep,name=Snapshots,proto3" json:"Snapshots,omitempty"`
+}
+
+func (x *ResponseGroupSnapshot) Reset() {
+	*x = ResponseGroupSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseGroupSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGroupSnapshot) ProtoMessage() {}
+
+func (x *ResponseGroupSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseGroupSnapshot.ProtoReflect.Descriptor instead.
+func (*ResponseGroupSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResponseGroupSnapshot) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResponseGroupSnapshot) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseGroupSnapshot) GetSnapshots() []*ResponseSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type ResponseListVolumes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Volumes []*Volume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+}
+
+func (x *ResponseListVolumes) Reset() {
+	*x = ResponseListVolumes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseListVolumes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListVolumes) ProtoMessage() {}
+
+func (x *ResponseListVolumes) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseListVolumes.ProtoReflect.Descriptor instead.
+func (*ResponseListVolumes) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResponseListVolumes) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+type Volume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QSDID          string         `protobuf:"bytes,1,opt,name=QSDID,proto3" json:"QSDID,omitempty"`
+	BackingImageID string         `protobuf:"bytes,2,opt,name=BackingImageID,proto3" json:"BackingImageID,omitempty"`
+	File           string         `protobuf:"bytes,3,opt,name=File,proto3" json:"File,omitempty"`
+	RefCount       int32          `protobuf:"varint,4,opt,name=RefCount,proto3" json:"RefCount,omitempty"`
+	VolumeRef      string         `protobuf:"bytes,5,opt,name=VolumeRef,proto3" json:"VolumeRef,omitempty"`
+	Depth          uint32         `protobuf:"varint,6,opt,name=Depth,proto3" json:"Depth,omitempty"`
+	Export         *ExportOptions `protobuf:"bytes,7,opt,name=Export,proto3" json:"Export,omitempty"`
+	IO             *IOOptions     `protobuf:"bytes,8,opt,name=IO,proto3" json:"IO,omitempty"`
+	// ReplicationState is empty for the volumes without replica
+	ReplicationState string `protobuf:"bytes,9,opt,name=ReplicationState,proto3" json:"ReplicationState,omitempty"`
+	ReplicaPeer      string `protobuf:"bytes,10,opt,name=ReplicaPeer,proto3" json:"ReplicaPeer,omitempty"`
+	// ReplicationLag are the bytes still to copy on the replica
+	ReplicationLag int64 `protobuf:"varint,11,opt,name=ReplicationLag,proto3" json:"ReplicationLag,omitempty"`
+	// LastSync is the time in nanoseconds of the last asynchronous sync
+	LastSync int64 `protobuf:"varint,12,opt,name=LastSync,proto3" json:"LastSync,omitempty"`
+	// Exports counts the vhost-user exports of the volume for the VMs
+	Exports int32 `protobuf:"varint,13,opt,name=Exports,proto3" json:"Exports,omitempty"`
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_qsd_qsd_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_qsd_qsd_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_pkg_qsd_qsd_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Volume) GetQSDID() string {
+	if x != nil {
+		return x.QSDID
+	}
+	return ""
+}
+
+func (x *Volume) GetBackingImageID() string {
+	if x != nil {
+		return x.BackingImageID
+	}
+	return ""
+}
+
+func (x *Volume) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Volume) GetRefCount() int32 {
+	if x != nil {
+		return x.RefCount
+	}
+	return 0
+}
+
+func (x *Volume) GetVolumeRef() string {
+	if x != nil {
+		return x.VolumeRef
+	}
+	return ""
+}
+
+func (x *Volume) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Volume) GetExport() *ExportOptions {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *Volume) GetIO() *IOOptions {
+	if x != nil {
+		return x.IO
+	}
+	return nil
+}
+
+func (x *Volume) GetReplicationState() string {
+	if x != nil {
+		return x.ReplicationState
+	}
+	return ""
+}
+
+func (x *Volume) GetReplicaPeer() string {
+	if x != nil {
+		return x.ReplicaPeer
+	}
+	return ""
+}
+
+func (x *Volume) GetReplicationLag() int64 {
+	if x != nil {
+		return x.ReplicationLag
+	}
+	return 0
+}
+
+func (x *Volume) GetLastSync() int64 {
+	if x != nil {
+		return x.LastSync
+	}
+	return 0
+}
+
+func (x *Volume) GetExports() int32 {
+	if x != nil {
+		return x.Exports
+	}
+	return 0
+}
+
+var File_pkg_qsd_qsd_proto protoreflect.FileDescriptor
+
+var file_pkg_qsd_qsd_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x73, 0x64, 0x2f, 0x71, 0x73, 0x64, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x22, 0xd5, 0x02, 0x0a, 0x05, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x46, 0x72, 0x6f, 0x6d,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x44,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x26, 0x0a, 0x0e,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x72, 0x69, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
+	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x2e, 0x0a, 0x02, 0x49, 0x4f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x49, 0x4f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x02, 0x49, 0x4f,
+	0x22, 0x87, 0x01, 0x0a, 0x09, 0x49, 0x4f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4e, 0x6f, 0x46, 0x6c, 0x75, 0x73, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4e, 0x6f, 0x46,
+	0x6c, 0x75, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x41, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x41, 0x69, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x5a, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x44, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x5a, 0x65, 0x72, 0x6f, 0x65, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0d, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x4e, 0x75, 0x6d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x4e, 0x75, 0x6d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x4c, 0x6f,
+	0x67, 0x69, 0x63, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x74,
+	0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x69,
+	0x73, 0x63, 0x61, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x44, 0x69, 0x73,
+	0x63, 0x61, 0x72, 0x64, 0x22, 0x5e, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44,
+	0x12, 0x26, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x22, 0x5c, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x3b, 0x0a, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65,
+	0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x22, 0x4a, 0x0a, 0x0c, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x1e,
+	0x0a, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x22, 0xa8,
+	0x01, 0x0a, 0x16, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x61, 0x73,
+	0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x42, 0x61, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49,
+	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x61, 0x78,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x4d,
+	0x61, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x3d, 0x0a, 0x0b, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x77, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72,
+	0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x68, 0x0a, 0x0c, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a,
+	0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x9a, 0x01, 0x0a, 0x0e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49, 0x44, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x56, 0x69, 0x72,
+	0x74, 0x75, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x6f, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x49,
+	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x22, 0xa1, 0x01, 0x0a, 0x11, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4e, 0x42, 0x44, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x54,
+	0x4c, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x54, 0x4c, 0x53, 0x22, 0xc3, 0x02,
+	0x0a, 0x0d, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x48,
+	0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x4c, 0x53, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x54, 0x4c, 0x53, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x56, 0x43,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x56, 0x43, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66,
+	0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x02, 0x49, 0x4f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b,
+	0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x4f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x02, 0x49, 0x4f, 0x22, 0xe9, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49,
+	0x44, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73,
+	0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x2e, 0x0a, 0x02, 0x49, 0x4f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6c,
+	0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73,
+	0x64, 0x2e, 0x49, 0x4f, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x02, 0x49, 0x4f, 0x22,
+	0xb1, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49,
+	0x44, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x4c, 0x53,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x54, 0x4c, 0x53, 0x12, 0x14, 0x0a, 0x05, 0x41,
+	0x73, 0x79, 0x6e, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x41, 0x73, 0x79, 0x6e,
+	0x63, 0x12, 0x10, 0x0a, 0x03, 0x52, 0x50, 0x4f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x52, 0x50, 0x4f, 0x22, 0x84, 0x02, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44,
+	0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x56,
+	0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x50, 0x56, 0x43, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a,
+	0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67,
+	0x2e, 0x71, 0x73, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x02, 0x49, 0x4f,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72,
+	0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x4f, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x02, 0x49, 0x4f, 0x22, 0x64, 0x0a, 0x0c, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x22, 0x52, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x0e, 0x0a, 0x0c, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x42, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x22, 0x30, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45,
+	0x47, 0x52, 0x41, 0x44, 0x45, 0x44, 0x10, 0x02, 0x22, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb4, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0x90, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x43, 0x0a,
+	0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
+	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x22, 0x4c, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x6c, 0x69,
+	0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64,
+	0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73,
+	0x22, 0xc2, 0x03, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x51,
+	0x53, 0x44, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x53, 0x44, 0x49,
+	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x69, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x52, 0x65, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x52, 0x65, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x52, 0x65, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x3a, 0x0a,
+	0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e,
+	0x71, 0x73, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x02, 0x49, 0x4f, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
+	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x4f, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x02, 0x49, 0x4f, 0x12, 0x2a, 0x0a, 0x10, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x50, 0x65, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x50, 0x65, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x12,
+	0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x32, 0xcd, 0x0f, 0x0a, 0x0a, 0x51, 0x73, 0x64, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
+	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
+	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x56, 0x68, 0x6f, 0x73, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
+	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
+	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
+	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e,
+	0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e,
+	0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d,
+	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
+	0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
+	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a,
+	0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b,
+	0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x1a, 0x25,
+	0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67,
+	0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x22,
+	0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67,
+	0x2e, 0x71, 0x73, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x1a, 0x2a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
+	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x52, 0x0a, 0x0c, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
+	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x10, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x2e, 0x61, 0x6c, 0x69,
+	0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66,
+	0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x67, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
+	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
+	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x61, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x73, 0x12, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
+	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x28, 0x2e, 0x61, 0x6c, 0x69, 0x63,
+	0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x73, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12,
+	0x21, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b,
+	0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x23, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0c, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x63,
+	0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x23, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63,
+	0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65,
+	0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x09, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4e, 0x42, 0x44, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72,
+	0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x1a, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4e, 0x42, 0x44, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0f,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x42, 0x44, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b,
+	0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c,
+	0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73,
+	0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d,
+	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x22, 0x2e,
+	0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e,
+	0x71, 0x73, 0x64, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e,
+	0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x12, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73,
+	0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66,
+	0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4e, 0x42, 0x44, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x22,
+	0x00, 0x12, 0x5b, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e,
+	0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1d, 0x2e,
+	0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e,
+	0x71, 0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70,
+	0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a,
+	0x0b, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x1a, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65,
+	0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0c, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x63,
+	0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1d, 0x2e, 0x61,
+	0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71,
+	0x73, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x54, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x21, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69, 0x2e, 0x70, 0x6b,
+	0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2e, 0x63, 0x73, 0x69,
+	0x2e, 0x70, 0x6b, 0x67, 0x2e, 0x71, 0x73, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x00, 0x30, 0x01, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x66, 0x72, 0x2f, 0x63, 0x73, 0x69, 0x2d,
+	0x71, 0x73, 0x64, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x71, 0x73, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_qsd_qsd_proto_rawDescOnce sync.Once
+	file_pkg_qsd_qsd_proto_rawDescData = file_pkg_qsd_qsd_proto_rawDesc
+)
+
+func file_pkg_qsd_qsd_proto_rawDescGZIP() []byte {
+	file_pkg_qsd_qsd_proto_rawDescOnce.Do(func() {
+		file_pkg_qsd_qsd_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_qsd_qsd_proto_rawDescData)
+	})
+	return file_pkg_qsd_qsd_proto_rawDescData
+}
+
+var file_pkg_qsd_qsd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_qsd_qsd_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_pkg_qsd_qsd_proto_goTypes = []interface{}{
+	(ResponseHealth_Status)(0),     // 0: alicefr.csi.pkg.qsd.ResponseHealth.Status
+	(*Image)(nil),                  // 1: alicefr.csi.pkg.qsd.Image
+	(*IOOptions)(nil),              // 2: alicefr.csi.pkg.qsd.IOOptions
+	(*ExportOptions)(nil),          // 3: alicefr.csi.pkg.qsd.ExportOptions
+	(*Snapshot)(nil),               // 4: alicefr.csi.pkg.qsd.Snapshot
+	(*GroupSnapshot)(nil),          // 5: alicefr.csi.pkg.qsd.GroupSnapshot
+	(*RevertParams)(nil),           // 6: alicefr.csi.pkg.qsd.RevertParams
+	(*SnapshotMetadataParams)(nil), // 7: alicefr.csi.pkg.qsd.SnapshotMetadataParams
+	(*BlockExtent)(nil),            // 8: alicefr.csi.pkg.qsd.BlockExtent
+	(*BlockMetadataPage)(nil),      // 9: alicefr.csi.pkg.qsd.BlockMetadataPage
+	(*BackupParams)(nil),           // 10: alicefr.csi.pkg.qsd.BackupParams
+	(*ResponseBackup)(nil),         // 11: alicefr.csi.pkg.qsd.ResponseBackup
+	(*RestoreParams)(nil),          // 12: alicefr.csi.pkg.qsd.RestoreParams
+	(*ResponseNBDExport)(nil),      // 13: alicefr.csi.pkg.qsd.ResponseNBDExport
+	(*MigrateParams)(nil),          // 14: alicefr.csi.pkg.qsd.MigrateParams
+	(*ReplicaParams)(nil),          // 15: alicefr.csi.pkg.qsd.ReplicaParams
+	(*ReplicationParams)(nil),      // 16: alicefr.csi.pkg.qsd.ReplicationParams
+	(*ImportParams)(nil),           // 17: alicefr.csi.pkg.qsd.ImportParams
+	(*ExportParams)(nil),           // 18: alicefr.csi.pkg.qsd.ExportParams
+	(*Progress)(nil),               // 19: alicefr.csi.pkg.qsd.Progress
+	(*ListVolumesParams)(nil),      // 20: alicefr.csi.pkg.qsd.ListVolumesParams
+	(*HealthParams)(nil),           // 21: alicefr.csi.pkg.qsd.HealthParams
+	(*ResponseHealth)(nil),         // 22: alicefr.csi.pkg.qsd.ResponseHealth
+	(*Response)(nil),               // 23: alicefr.csi.pkg.qsd.Response
+	(*ResponseSnapshot)(nil),       // 24: alicefr.csi.pkg.qsd.ResponseSnapshot
+	(*ResponseGroupSnapshot)(nil),  // 25: alicefr.csi.pkg.qsd.ResponseGroupSnapshot
+	(*ResponseListVolumes)(nil),    // 26: alicefr.csi.pkg.qsd.ResponseListVolumes
+	(*Volume)(nil),                 // 27: alicefr.csi.pkg.qsd.Volume
+}
+var file_pkg_qsd_qsd_proto_depIdxs = []int32{
+	3,  // 0: alicefr.csi.pkg.qsd.Image.Export:type_name -> alicefr.csi.pkg.qsd.ExportOptions
+	2,  // 1: alicefr.csi.pkg.qsd.Image.IO:type_name -> alicefr.csi.pkg.qsd.IOOptions
+	4,  // 2: alicefr.csi.pkg.qsd.GroupSnapshot.Snapshots:type_name -> alicefr.csi.pkg.qsd.Snapshot
+	8,  // 3: alicefr.csi.pkg.qsd.BlockMetadataPage.Extents:type_name -> alicefr.csi.pkg.qsd.BlockExtent
+	3,  // 4: alicefr.csi.pkg.qsd.MigrateParams.Export:type_name -> alicefr.csi.pkg.qsd.ExportOptions
+	2,  // 5: alicefr.csi.pkg.qsd.MigrateParams.IO:type_name -> alicefr.csi.pkg.qsd.IOOptions
+	3,  // 6: alicefr.csi.pkg.qsd.ReplicaParams.Export:type_name -> alicefr.csi.pkg.qsd.ExportOptions
+	2,  // 7: alicefr.csi.pkg.qsd.ReplicaParams.IO:type_name -> alicefr.csi.pkg.qsd.IOOptions
+	3,  // 8: alicefr.csi.pkg.qsd.ImportParams.Export:type_name -> alicefr.csi.pkg.qsd.ExportOptions
+	2,  // 9: alicefr.csi.pkg.qsd.ImportParams.IO:type_name -> alicefr.csi.pkg.qsd.IOOptions
+	0,  // 10: alicefr.csi.pkg.qsd.ResponseHealth.status:type_name -> alicefr.csi.pkg.qsd.ResponseHealth.Status
+	24, // 11: alicefr.csi.pkg.qsd.ResponseGroupSnapshot.Snapshots:type_name -> alicefr.csi.pkg.qsd.ResponseSnapshot
+	27, // 12: alicefr.csi.pkg.qsd.ResponseListVolumes.volumes:type_name -> alicefr.csi.pkg.qsd.Volume
+	3,  // 13: alicefr.csi.pkg.qsd.Volume.Export:type_name -> alicefr.csi.pkg.qsd.ExportOptions
+	2,  // 14: alicefr.csi.pkg.qsd.Volume.IO:type_name -> alicefr.csi.pkg.qsd.IOOptions
+	1,  // 15: alicefr.csi.pkg.qsd.QsdService.CreateVolume:input_type -> alicefr.csi.pkg.qsd.Image
+	1,  // 16: alicefr.csi.pkg.qsd.QsdService.ExposeVhostUser:input_type -> alicefr.csi.pkg.qsd.Image
+	1,  // 17: alicefr.csi.pkg.qsd.QsdService.DeleteVolume:input_type -> alicefr.csi.pkg.qsd.Image
+	1,  // 18: alicefr.csi.pkg.qsd.QsdService.DeleteExporter:input_type -> alicefr.csi.pkg.qsd.Image
+	4,  // 19: alicefr.csi.pkg.qsd.QsdService.CreateSnapshot:input_type -> alicefr.csi.pkg.qsd.Snapshot
+	5,  // 20: alicefr.csi.pkg.qsd.QsdService.CreateGroupSnapshot:input_type -> alicefr.csi.pkg.qsd.GroupSnapshot
+	4,  // 21: alicefr.csi.pkg.qsd.QsdService.DeleteSnapshot:input_type -> alicefr.csi.pkg.qsd.Snapshot
+	6,  // 22: alicefr.csi.pkg.qsd.QsdService.RevertVolume:input_type -> alicefr.csi.pkg.qsd.RevertParams
+	7,  // 23: alicefr.csi.pkg.qsd.QsdService.SnapshotMetadata:input_type -> alicefr.csi.pkg.qsd.SnapshotMetadataParams
+	1,  // 24: alicefr.csi.pkg.qsd.QsdService.ExposeSnapshot:input_type -> alicefr.csi.pkg.qsd.Image
+	20, // 25: alicefr.csi.pkg.qsd.QsdService.ListVolumes:input_type -> alicefr.csi.pkg.qsd.ListVolumesParams
+	21, // 26: alicefr.csi.pkg.qsd.QsdService.Health:input_type -> alicefr.csi.pkg.qsd.HealthParams
+	10, // 27: alicefr.csi.pkg.qsd.QsdService.BackupVolume:input_type -> alicefr.csi.pkg.qsd.BackupParams
+	12, // 28: alicefr.csi.pkg.qsd.QsdService.RestoreVolume:input_type -> alicefr.csi.pkg.qsd.RestoreParams
+	1,  // 29: alicefr.csi.pkg.qsd.QsdService.ExportNBD:input_type -> alicefr.csi.pkg.qsd.Image
+	1,  // 30: alicefr.csi.pkg.qsd.QsdService.DeleteNBDExport:input_type -> alicefr.csi.pkg.qsd.Image
+	14, // 31: alicefr.csi.pkg.qsd.QsdService.MigrateVolume:input_type -> alicefr.csi.pkg.qsd.MigrateParams
+	15, // 32: alicefr.csi.pkg.qsd.QsdService.CreateReplica:input_type -> alicefr.csi.pkg.qsd.ReplicaParams
+	16, // 33: alicefr.csi.pkg.qsd.QsdService.StartReplication:input_type -> alicefr.csi.pkg.qsd.ReplicationParams
+	1,  // 34: alicefr.csi.pkg.qsd.QsdService.PromoteReplica:input_type -> alicefr.csi.pkg.qsd.Image
+	1,  // 35: alicefr.csi.pkg.qsd.QsdService.SyncReplica:input_type -> alicefr.csi.pkg.qsd.Image
+	17, // 36: alicefr.csi.pkg.qsd.QsdService.ImportVolume:input_type -> alicefr.csi.pkg.qsd.ImportParams
+	18, // 37: alicefr.csi.pkg.qsd.QsdService.ExportVolume:input_type -> alicefr.csi.pkg.qsd.ExportParams
+	23, // 38: alicefr.csi.pkg.qsd.QsdService.CreateVolume:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 39: alicefr.csi.pkg.qsd.QsdService.ExposeVhostUser:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 40: alicefr.csi.pkg.qsd.QsdService.DeleteVolume:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 41: alicefr.csi.pkg.qsd.QsdService.DeleteExporter:output_type -> alicefr.csi.pkg.qsd.Response
+	24, // 42: alicefr.csi.pkg.qsd.QsdService.CreateSnapshot:output_type -> alicefr.csi.pkg.qsd.ResponseSnapshot
+	25, // 43: alicefr.csi.pkg.qsd.QsdService.CreateGroupSnapshot:output_type -> alicefr.csi.pkg.qsd.ResponseGroupSnapshot
+	23, // 44: alicefr.csi.pkg.qsd.QsdService.DeleteSnapshot:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 45: alicefr.csi.pkg.qsd.QsdService.RevertVolume:output_type -> alicefr.csi.pkg.qsd.Response
+	9,  // 46: alicefr.csi.pkg.qsd.QsdService.SnapshotMetadata:output_type -> alicefr.csi.pkg.qsd.BlockMetadataPage
+	23, // 47: alicefr.csi.pkg.qsd.QsdService.ExposeSnapshot:output_type -> alicefr.csi.pkg.qsd.Response
+	26, // 48: alicefr.csi.pkg.qsd.QsdService.ListVolumes:output_type -> alicefr.csi.pkg.qsd.ResponseListVolumes
+	22, // 49: alicefr.csi.pkg.qsd.QsdService.Health:output_type -> alicefr.csi.pkg.qsd.ResponseHealth
+	11, // 50: alicefr.csi.pkg.qsd.QsdService.BackupVolume:output_type -> alicefr.csi.pkg.qsd.ResponseBackup
+	23, // 51: alicefr.csi.pkg.qsd.QsdService.RestoreVolume:output_type -> alicefr.csi.pkg.qsd.Response
+	13, // 52: alicefr.csi.pkg.qsd.QsdService.ExportNBD:output_type -> alicefr.csi.pkg.qsd.ResponseNBDExport
+	23, // 53: alicefr.csi.pkg.qsd.QsdService.DeleteNBDExport:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 54: alicefr.csi.pkg.qsd.QsdService.MigrateVolume:output_type -> alicefr.csi.pkg.qsd.Response
+	13, // 55: alicefr.csi.pkg.qsd.QsdService.CreateReplica:output_type -> alicefr.csi.pkg.qsd.ResponseNBDExport
+	23, // 56: alicefr.csi.pkg.qsd.QsdService.StartReplication:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 57: alicefr.csi.pkg.qsd.QsdService.PromoteReplica:output_type -> alicefr.csi.pkg.qsd.Response
+	23, // 58: alicefr.csi.pkg.qsd.QsdService.SyncReplica:output_type -> alicefr.csi.pkg.qsd.Response
+	19, // 59: alicefr.csi.pkg.qsd.QsdService.ImportVolume:output_type -> alicefr.csi.pkg.qsd.Progress
+	19, // 60: alicefr.csi.pkg.qsd.QsdService.ExportVolume:output_type -> alicefr.csi.pkg.qsd.Progress
+	38, // [38:61] is the sub-list for method output_type
+	15, // [15:38] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_pkg_qsd_qsd_proto_init() }
@@ -511,7 +2592,7 @@ func file_pkg_qsd_qsd_proto_init() {
 			}
 		}
 		file_pkg_qsd_qsd_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Snapshot); i {
+			switch v := v.(*IOOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -523,7 +2604,7 @@ func file_pkg_qsd_qsd_proto_init() {
 			}
 		}
 		file_pkg_qsd_qsd_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListVolumesParams); i {
+			switch v := v.(*ExportOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -535,7 +2616,7 @@ func file_pkg_qsd_qsd_proto_init() {
 			}
 		}
 		file_pkg_qsd_qsd_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*Snapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -547,7 +2628,7 @@ func file_pkg_qsd_qsd_proto_init() {
 			}
 		}
 		file_pkg_qsd_qsd_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseListVolumes); i {
+			switch v := v.(*GroupSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -559,6 +2640,258 @@ func file_pkg_qsd_qsd_proto_init() {
 			}
 		}
 		file_pkg_qsd_qsd_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevertParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotMetadataParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockExtent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockMetadataPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseBackup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseNBDExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrateParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Progress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVolumesParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseGroupSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseListVolumes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_qsd_qsd_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Volume); i {
 			case 0:
 				return &v.state
@@ -576,13 +2909,14 @@ func file_pkg_qsd_qsd_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_qsd_qsd_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pkg_qsd_qsd_proto_goTypes,
 		DependencyIndexes: file_pkg_qsd_qsd_proto_depIdxs,
+		EnumInfos:         file_pkg_qsd_qsd_proto_enumTypes,
 		MessageInfos:      file_pkg_qsd_qsd_proto_msgTypes,
 	}.Build()
 	File_pkg_qsd_qsd_proto = out.File
@@ -22,9 +22,25 @@ type QsdServiceClient interface {
 	ExposeVhostUser(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
 	DeleteVolume(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
 	DeleteExporter(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
-	CreateSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*Response, error)
+	CreateSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*ResponseSnapshot, error)
+	CreateGroupSnapshot(ctx context.Context, in *GroupSnapshot, opts ...grpc.CallOption) (*ResponseGroupSnapshot, error)
 	DeleteSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*Response, error)
+	RevertVolume(ctx context.Context, in *RevertParams, opts ...grpc.CallOption) (*Response, error)
+	SnapshotMetadata(ctx context.Context, in *SnapshotMetadataParams, opts ...grpc.CallOption) (QsdService_SnapshotMetadataClient, error)
+	ExposeSnapshot(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
 	ListVolumes(ctx context.Context, in *ListVolumesParams, opts ...grpc.CallOption) (*ResponseListVolumes, error)
+	Health(ctx context.Context, in *HealthParams, opts ...grpc.CallOption) (*ResponseHealth, error)
+	BackupVolume(ctx context.Context, in *BackupParams, opts ...grpc.CallOption) (*ResponseBackup, error)
+	RestoreVolume(ctx context.Context, in *RestoreParams, opts ...grpc.CallOption) (*Response, error)
+	ExportNBD(ctx context.Context, in *Image, opts ...grpc.CallOption) (*ResponseNBDExport, error)
+	DeleteNBDExport(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
+	MigrateVolume(ctx context.Context, in *MigrateParams, opts ...grpc.CallOption) (*Response, error)
+	CreateReplica(ctx context.Context, in *ReplicaParams, opts ...grpc.CallOption) (*ResponseNBDExport, error)
+	StartReplication(ctx context.Context, in *ReplicationParams, opts ...grpc.CallOption) (*Response, error)
+	PromoteReplica(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
+	SyncReplica(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error)
+	ImportVolume(ctx context.Context, in *ImportParams, opts ...grpc.CallOption) (QsdService_ImportVolumeClient, error)
+	ExportVolume(ctx context.Context, in *ExportParams, opts ...grpc.CallOption) (QsdService_ExportVolumeClient, error)
 }
 
 type qsdServiceClient struct {
@@ -71,8 +87,8 @@ func (c *qsdServiceClient) DeleteExporter(ctx context.Context, in *Image, opts .
 	return out, nil
 }
 
-func (c *qsdServiceClient) CreateSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*Response, error) {
-	out := new(Response)
+func (c *qsdServiceClient) CreateSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*ResponseSnapshot, error) {
+	out := new(ResponseSnapshot)
 	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/CreateSnapshot", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -80,6 +96,15 @@ func (c *qsdServiceClient) CreateSnapshot(ctx context.Context, in *Snapshot, opt
 	return out, nil
 }
 
+func (c *qsdServiceClient) CreateGroupSnapshot(ctx context.Context, in *GroupSnapshot, opts ...grpc.CallOption) (*ResponseGroupSnapshot, error) {
+	out := new(ResponseGroupSnapshot)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/CreateGroupSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *qsdServiceClient) DeleteSnapshot(ctx context.Context, in *Snapshot, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/DeleteSnapshot", in, out, opts...)
@@ -89,6 +114,56 @@ func (c *qsdServiceClient) DeleteSnapshot(ctx context.Context, in *Snapshot, opt
 	return out, nil
 }
 
+func (c *qsdServiceClient) RevertVolume(ctx context.Context, in *RevertParams, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/RevertVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) SnapshotMetadata(ctx context.Context, in *SnapshotMetadataParams, opts ...grpc.CallOption) (QsdService_SnapshotMetadataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QsdService_ServiceDesc.Streams[0], "/alicefr.csi.pkg.qsd.QsdService/SnapshotMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &qsdServiceSnapshotMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QsdService_SnapshotMetadataClient interface {
+	Recv() (*BlockMetadataPage, error)
+	grpc.ClientStream
+}
+
+type qsdServiceSnapshotMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *qsdServiceSnapshotMetadataClient) Recv() (*BlockMetadataPage, error) {
+	m := new(BlockMetadataPage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *qsdServiceClient) ExposeSnapshot(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/ExposeSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *qsdServiceClient) ListVolumes(ctx context.Context, in *ListVolumesParams, opts ...grpc.CallOption) (*ResponseListVolumes, error) {
 	out := new(ResponseListVolumes)
 	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/ListVolumes", in, out, opts...)
@@ -98,6 +173,160 @@ func (c *qsdServiceClient) ListVolumes(ctx context.Context, in *ListVolumesParam
 	return out, nil
 }
 
+func (c *qsdServiceClient) Health(ctx context.Context, in *HealthParams, opts ...grpc.CallOption) (*ResponseHealth, error) {
+	out := new(ResponseHealth)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) BackupVolume(ctx context.Context, in *BackupParams, opts ...grpc.CallOption) (*ResponseBackup, error) {
+	out := new(ResponseBackup)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/BackupVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) RestoreVolume(ctx context.Context, in *RestoreParams, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/RestoreVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) ExportNBD(ctx context.Context, in *Image, opts ...grpc.CallOption) (*ResponseNBDExport, error) {
+	out := new(ResponseNBDExport)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/ExportNBD", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) DeleteNBDExport(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/DeleteNBDExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) MigrateVolume(ctx context.Context, in *MigrateParams, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/MigrateVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) CreateReplica(ctx context.Context, in *ReplicaParams, opts ...grpc.CallOption) (*ResponseNBDExport, error) {
+	out := new(ResponseNBDExport)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/CreateReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) StartReplication(ctx context.Context, in *ReplicationParams, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/StartReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) PromoteReplica(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/PromoteReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) SyncReplica(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/alicefr.csi.pkg.qsd.QsdService/SyncReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qsdServiceClient) ImportVolume(ctx context.Context, in *ImportParams, opts ...grpc.CallOption) (QsdService_ImportVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QsdService_ServiceDesc.Streams[1], "/alicefr.csi.pkg.qsd.QsdService/ImportVolume", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &qsdServiceImportVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QsdService_ImportVolumeClient interface {
+	Recv() (*Progress, error)
+	grpc.ClientStream
+}
+
+type qsdServiceImportVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *qsdServiceImportVolumeClient) Recv() (*Progress, error) {
+	m := new(Progress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *qsdServiceClient) ExportVolume(ctx context.Context, in *ExportParams, opts ...grpc.CallOption) (QsdService_ExportVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QsdService_ServiceDesc.Streams[2], "/alicefr.csi.pkg.qsd.QsdService/ExportVolume", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &qsdServiceExportVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QsdService_ExportVolumeClient interface {
+	Recv() (*Progress, error)
+	grpc.ClientStream
+}
+
+type qsdServiceExportVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *qsdServiceExportVolumeClient) Recv() (*Progress, error) {
+	m := new(Progress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // QsdServiceServer is the server API for QsdService service.
 // All implementations must embed UnimplementedQsdServiceServer
 // for forward compatibility
@@ -106,9 +335,25 @@ type QsdServiceServer interface {
 	ExposeVhostUser(context.Context, *Image) (*Response, error)
 	DeleteVolume(context.Context, *Image) (*Response, error)
 	DeleteExporter(context.Context, *Image) (*Response, error)
-	CreateSnapshot(context.Context, *Snapshot) (*Response, error)
+	CreateSnapshot(context.Context, *Snapshot) (*ResponseSnapshot, error)
+	CreateGroupSnapshot(context.Context, *GroupSnapshot) (*ResponseGroupSnapshot, error)
 	DeleteSnapshot(context.Context, *Snapshot) (*Response, error)
+	RevertVolume(context.Context, *RevertParams) (*Response, error)
+	SnapshotMetadata(*SnapshotMetadataParams, QsdService_SnapshotMetadataServer) error
+	ExposeSnapshot(context.Context, *Image) (*Response, error)
 	ListVolumes(context.Context, *ListVolumesParams) (*ResponseListVolumes, error)
+	Health(context.Context, *HealthParams) (*ResponseHealth, error)
+	BackupVolume(context.Context, *BackupParams) (*ResponseBackup, error)
+	RestoreVolume(context.Context, *RestoreParams) (*Response, error)
+	ExportNBD(context.Context, *Image) (*ResponseNBDExport, error)
+	DeleteNBDExport(context.Context, *Image) (*Response, error)
+	MigrateVolume(context.Context, *MigrateParams) (*Response, error)
+	CreateReplica(context.Context, *ReplicaParams) (*ResponseNBDExport, error)
+	StartReplication(context.Context, *ReplicationParams) (*Response, error)
+	PromoteReplica(context.Context, *Image) (*Response, error)
+	SyncReplica(context.Context, *Image) (*Response, error)
+	ImportVolume(*ImportParams, QsdService_ImportVolumeServer) error
+	ExportVolume(*ExportParams, QsdService_ExportVolumeServer) error
 	mustEmbedUnimplementedQsdServiceServer()
 }
 
@@ -128,15 +373,63 @@ func (UnimplementedQsdServiceServer) DeleteVolume(context.Context, *Image) (*Res
 func (UnimplementedQsdServiceServer) DeleteExporter(context.Context, *Image) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteExporter not implemented")
 }
-func (UnimplementedQsdServiceServer) CreateSnapshot(context.Context, *Snapshot) (*Response, error) {
+func (UnimplementedQsdServiceServer) CreateSnapshot(context.Context, *Snapshot) (*ResponseSnapshot, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
 }
+func (UnimplementedQsdServiceServer) CreateGroupSnapshot(context.Context, *GroupSnapshot) (*ResponseGroupSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupSnapshot not implemented")
+}
 func (UnimplementedQsdServiceServer) DeleteSnapshot(context.Context, *Snapshot) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
 }
+func (UnimplementedQsdServiceServer) RevertVolume(context.Context, *RevertParams) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertVolume not implemented")
+}
+func (UnimplementedQsdServiceServer) SnapshotMetadata(*SnapshotMetadataParams, QsdService_SnapshotMetadataServer) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotMetadata not implemented")
+}
+func (UnimplementedQsdServiceServer) ExposeSnapshot(context.Context, *Image) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExposeSnapshot not implemented")
+}
 func (UnimplementedQsdServiceServer) ListVolumes(context.Context, *ListVolumesParams) (*ResponseListVolumes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVolumes not implemented")
 }
+func (UnimplementedQsdServiceServer) Health(context.Context, *HealthParams) (*ResponseHealth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedQsdServiceServer) BackupVolume(context.Context, *BackupParams) (*ResponseBackup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupVolume not implemented")
+}
+func (UnimplementedQsdServiceServer) RestoreVolume(context.Context, *RestoreParams) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVolume not implemented")
+}
+func (UnimplementedQsdServiceServer) ExportNBD(context.Context, *Image) (*ResponseNBDExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportNBD not implemented")
+}
+func (UnimplementedQsdServiceServer) DeleteNBDExport(context.Context, *Image) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNBDExport not implemented")
+}
+func (UnimplementedQsdServiceServer) MigrateVolume(context.Context, *MigrateParams) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateVolume not implemented")
+}
+func (UnimplementedQsdServiceServer) CreateReplica(context.Context, *ReplicaParams) (*ResponseNBDExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReplica not implemented")
+}
+func (UnimplementedQsdServiceServer) StartReplication(context.Context, *ReplicationParams) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReplication not implemented")
+}
+func (UnimplementedQsdServiceServer) PromoteReplica(context.Context, *Image) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteReplica not implemented")
+}
+func (UnimplementedQsdServiceServer) SyncReplica(context.Context, *Image) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncReplica not implemented")
+}
+func (UnimplementedQsdServiceServer) ImportVolume(*ImportParams, QsdService_ImportVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportVolume not implemented")
+}
+func (UnimplementedQsdServiceServer) ExportVolume(*ExportParams, QsdService_ExportVolumeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportVolume not implemented")
+}
 func (UnimplementedQsdServiceServer) mustEmbedUnimplementedQsdServiceServer() {}
 
 // UnsafeQsdServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -240,6 +533,24 @@ func _QsdService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QsdService_CreateGroupSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).CreateGroupSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/CreateGroupSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).CreateGroupSnapshot(ctx, req.(*GroupSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QsdService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Snapshot)
 	if err := dec(in); err != nil {
@@ -258,6 +569,63 @@ func _QsdService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QsdService_RevertVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).RevertVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/RevertVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).RevertVolume(ctx, req.(*RevertParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_SnapshotMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotMetadataParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QsdServiceServer).SnapshotMetadata(m, &qsdServiceSnapshotMetadataServer{stream})
+}
+
+type QsdService_SnapshotMetadataServer interface {
+	Send(*BlockMetadataPage) error
+	grpc.ServerStream
+}
+
+type qsdServiceSnapshotMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *qsdServiceSnapshotMetadataServer) Send(m *BlockMetadataPage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QsdService_ExposeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).ExposeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/ExposeSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).ExposeSnapshot(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QsdService_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListVolumesParams)
 	if err := dec(in); err != nil {
@@ -276,6 +644,228 @@ func _QsdService_ListVolumes_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QsdService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).Health(ctx, req.(*HealthParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_BackupVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).BackupVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/BackupVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).BackupVolume(ctx, req.(*BackupParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_RestoreVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).RestoreVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/RestoreVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).RestoreVolume(ctx, req.(*RestoreParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_ExportNBD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).ExportNBD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/ExportNBD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).ExportNBD(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_DeleteNBDExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).DeleteNBDExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/DeleteNBDExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).DeleteNBDExport(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_MigrateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).MigrateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/MigrateVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).MigrateVolume(ctx, req.(*MigrateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_CreateReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicaParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).CreateReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/CreateReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).CreateReplica(ctx, req.(*ReplicaParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_StartReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).StartReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/StartReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).StartReplication(ctx, req.(*ReplicationParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_PromoteReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).PromoteReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/PromoteReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).PromoteReplica(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_SyncReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QsdServiceServer).SyncReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/alicefr.csi.pkg.qsd.QsdService/SyncReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QsdServiceServer).SyncReplica(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QsdService_ImportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QsdServiceServer).ImportVolume(m, &qsdServiceImportVolumeServer{stream})
+}
+
+type QsdService_ImportVolumeServer interface {
+	Send(*Progress) error
+	grpc.ServerStream
+}
+
+type qsdServiceImportVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *qsdServiceImportVolumeServer) Send(m *Progress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QsdService_ExportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QsdServiceServer).ExportVolume(m, &qsdServiceExportVolumeServer{stream})
+}
+
+type QsdService_ExportVolumeServer interface {
+	Send(*Progress) error
+	grpc.ServerStream
+}
+
+type qsdServiceExportVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *qsdServiceExportVolumeServer) Send(m *Progress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // QsdService_ServiceDesc is the grpc.ServiceDesc for QsdService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,15 +893,83 @@ var QsdService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSnapshot",
 			Handler:    _QsdService_CreateSnapshot_Handler,
 		},
+		{
+			MethodName: "CreateGroupSnapshot",
+			Handler:    _QsdService_CreateGroupSnapshot_Handler,
+		},
 		{
 			MethodName: "DeleteSnapshot",
 			Handler:    _QsdService_DeleteSnapshot_Handler,
 		},
+		{
+			MethodName: "RevertVolume",
+			Handler:    _QsdService_RevertVolume_Handler,
+		},
+		{
+			MethodName: "ExposeSnapshot",
+			Handler:    _QsdService_ExposeSnapshot_Handler,
+		},
 		{
 			MethodName: "ListVolumes",
 			Handler:    _QsdService_ListVolumes_Handler,
 		},
+		{
+			MethodName: "Health",
+			Handler:    _QsdService_Health_Handler,
+		},
+		{
+			MethodName: "BackupVolume",
+			Handler:    _QsdService_BackupVolume_Handler,
+		},
+		{
+			MethodName: "RestoreVolume",
+			Handler:    _QsdService_RestoreVolume_Handler,
+		},
+		{
+			MethodName: "ExportNBD",
+			Handler:    _QsdService_ExportNBD_Handler,
+		},
+		{
+			MethodName: "DeleteNBDExport",
+			Handler:    _QsdService_DeleteNBDExport_Handler,
+		},
+		{
+			MethodName: "MigrateVolume",
+			Handler:    _QsdService_MigrateVolume_Handler,
+		},
+		{
+			MethodName: "CreateReplica",
+			Handler:    _QsdService_CreateReplica_Handler,
+		},
+		{
+			MethodName: "StartReplication",
+			Handler:    _QsdService_StartReplication_Handler,
+		},
+		{
+			MethodName: "PromoteReplica",
+			Handler:    _QsdService_PromoteReplica_Handler,
+		},
+		{
+			MethodName: "SyncReplica",
+			Handler:    _QsdService_SyncReplica_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SnapshotMetadata",
+			Handler:       _QsdService_SnapshotMetadata_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportVolume",
+			Handler:       _QsdService_ImportVolume_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportVolume",
+			Handler:       _QsdService_ExportVolume_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/qsd/qsd.proto",
 }
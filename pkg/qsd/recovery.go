@@ -0,0 +1,172 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// recoverTimeout bounds the replay of the block graph on a restarted daemon
+const recoverTimeout = 5 * time.Minute
+
+// health tracks the state of the qemu-storage-daemon backing the server
+type health struct {
+	status   ResponseHealth_Status
+	message  string
+	restarts int32
+}
+
+// SetDegraded marks the server as degraded while the qemu-storage-daemon is not available
+func (c *Server) SetDegraded(message string) {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	log.Warningf("Server degraded: %s", message)
+	c.health.status = ResponseHealth_DEGRADED
+	c.health.message = message
+}
+
+// Recover reconnects the server to a restarted qemu-storage-daemon and replays
+// the block graph and the exports from the state of the server. The health
+// checks are answered during the recovery.
+func (c *Server) Recover() error {
+	log.Infof("Recover the state of the qemu-storage-daemon")
+	if err := c.volManager.Reconnect(); err != nil {
+		return fmt.Errorf("Failed reconnecting to the qsd monitor: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), recoverTimeout)
+	defer cancel()
+	c.mu.Lock()
+	err := c.replay(ctx)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	c.health.status = ResponseHealth_SERVING
+	c.health.message = ""
+	c.health.restarts++
+	return nil
+}
+
+// replay recreates the nodes of the images starting from the base images and
+// exports again the volumes on the same vhost-user sockets
+func (c *Server) replay(ctx context.Context) error {
+	// The NBD exports of the migrations are lost with the daemon
+	c.nbdExports = make(map[string]int)
+	var images []*QCOWImage
+	for _, i := range c.images {
+		images = append(images, i)
+	}
+	// The backing node needs to exist before the overlays
+	sort.Slice(images, func(i, j int) bool { return images[i].Depth < images[j].Depth })
+	for _, i := range images {
+		var backing string
+		if i.BackingImageID != "" {
+			b, ok := c.images[i.BackingImageID]
+			if !ok {
+				return fmt.Errorf("Backing image %s of %s not found", i.BackingImageID, i.QSDID)
+			}
+			backing = b.QSDID
+		}
+		if err := c.volManager.AddImage(ctx, i.File, i.QSDID, backing, i.Options); err != nil {
+			return fmt.Errorf("Failed adding the node for the image %s: %v", i.File, err)
+		}
+	}
+	for id, socket := range c.exports {
+		i, ok := c.images[id]
+		if !ok {
+			return fmt.Errorf("Image %s for the export not found", id)
+		}
+		a, ok := c.images[c.activeLayers[id]]
+		if !ok {
+			return fmt.Errorf("Active layer for the volume %s not found", id)
+		}
+		// Remove the socket left by the previous instance of the daemon
+		if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := c.volManager.ExposeVhostUser(ctx, i.QSDID, a.QSDID, socket, true, i.Export); err != nil {
+			return fmt.Errorf("Failed exporting the volume %s: %v", id, err)
+		}
+	}
+	for id, publishes := range c.publishes {
+		i, ok := c.images[id]
+		if !ok {
+			return fmt.Errorf("Image %s for the export not found", id)
+		}
+		a, ok := c.images[c.activeLayers[id]]
+		if !ok {
+			return fmt.Errorf("Active layer for the volume %s not found", id)
+		}
+		for exportID, p := range publishes {
+			if err := os.Remove(p.Socket); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := c.volManager.ExposeVhostUser(ctx, publishID(i.QSDID, exportID), a.QSDID, p.Socket, p.Writable, i.Export); err != nil {
+				return fmt.Errorf("Failed exporting the volume %s for %s: %v", id, exportID, err)
+			}
+		}
+	}
+	for id, exports := range c.snapshotExports {
+		s, ok := c.images[id]
+		if !ok {
+			return fmt.Errorf("Snapshot %s for the export not found", id)
+		}
+		b, ok := c.images[s.BackingImageID]
+		if !ok {
+			return fmt.Errorf("Backing image of the snapshot %s not found", id)
+		}
+		for exportID, p := range exports {
+			if err := os.Remove(p.Socket); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := c.volManager.ExposeVhostUser(ctx, publishID(s.QSDID, exportID), b.QSDID, p.Socket, false, c.snapshotExportOptions(s)); err != nil {
+				return fmt.Errorf("Failed exporting the snapshot %s for %s: %v", id, exportID, err)
+			}
+		}
+	}
+	// A replication that cannot be restarted is reported as broken by ListVolumes
+	for id, r := range c.replications {
+		i, ok := c.images[id]
+		if !ok {
+			return fmt.Errorf("Replicated volume %s not found", id)
+		}
+		var err error
+		if r.Role == replicationReplica {
+			err = c.exposeReplica(ctx, id, i)
+		} else if !r.Async {
+			err = c.startReplication(ctx, id, i, r)
+		}
+		if err != nil {
+			log.Errorf("Failed restoring the replication of %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (c *Server) Health(ctx context.Context, _ *HealthParams) (*ResponseHealth, error) {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	return &ResponseHealth{
+		Status:   c.health.status,
+		Message:  c.health.message,
+		Restarts: c.health.restarts,
+	}, nil
+}
+
+// Ping checks that the server is not recovering the daemon and that the daemon
+// answers on the QMP monitor
+func (c *Server) Ping(ctx context.Context) error {
+	c.healthMu.Lock()
+	h := c.health
+	c.healthMu.Unlock()
+	if h.status != ResponseHealth_SERVING {
+		return fmt.Errorf("qemu-storage-daemon degraded: %s", h.message)
+	}
+	return c.volManager.Ping(ctx)
+}
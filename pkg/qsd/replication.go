@@ -0,0 +1,452 @@
+package qsd
+
+import (
+	context "context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles of the servers in the replication of a volume
+const (
+	replicationPrimary = "primary"
+	replicationReplica = "replica"
+)
+
+// States of the replication reported by ListVolumes
+const (
+	replicationSyncing = "Syncing"
+	replicationInSync  = "InSync"
+	replicationBroken  = "Broken"
+)
+
+// replication is the replication of a volume between the primary and the replica
+// servers. The primary mirrors the active layer of the volume on the NBD export of
+// the replica, the replica only contains the flattened volume.
+type replication struct {
+	Role string
+	// Host, Port, ExportName and TLS locate the export of the replica on the primary
+	Host       string
+	Port       string
+	ExportName string
+	TLS        bool
+	// Async replications copy every RPO the writes tracked by a dirty bitmap
+	Async bool
+	RPO   time.Duration
+	// Node is the active layer tracked by the bitmap since LastSync
+	Node      string
+	LastSync  time.Time
+	LastError string
+}
+
+// CreateReplica creates the empty replica of a volume and exports it writable over
+// NBD. The replica cannot be exported to the VMs until it is promoted.
+func (c *Server) CreateReplica(ctx context.Context, p *ReplicaParams) (*ResponseNBDExport, error) {
+	log.Infof("Create replica of the volume %s", p.VolumeID)
+	if err := checkOptions(p.Export, p.IO); err != nil {
+		return failedNBDExport(err.Error(), err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.replications[p.VolumeID]; ok && r.Role == replicationReplica {
+		log.Infof("Replica of the volume %s already created", p.VolumeID)
+		return c.replicaExport(p.VolumeID), nil
+	}
+	if _, ok := c.images[p.VolumeID]; ok {
+		errMessage := fmt.Sprintf("Volume %s already exists", p.VolumeID)
+		return failedNBDExport(errMessage, status.Error(codes.AlreadyExists, errMessage))
+	}
+	dir := fmt.Sprintf("%s/%s", imagesDir, p.VolumeID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		errMessage := fmt.Sprintf("Cannot create directory for the volume:%s", p.VolumeID)
+		return failedNBDExport(errMessage, err)
+	}
+	i := &QCOWImage{
+		File:      fmt.Sprintf("%s/%s", dir, diskImg),
+		QSDID:     generateQSDID(p.VolumeID),
+		VolumeRef: p.VolumeID,
+		PVC:       pvcName(&Image{PVCName: p.PVCName, PVCNamespace: p.PVCNamespace}),
+		Options:   nodeOptions(p.Export, p.IO),
+		Export:    p.Export,
+		Created:   time.Now(),
+	}
+	if err := c.volManager.CreateVolume(ctx, i.File, i.QSDID, fmt.Sprintf("%d", p.Size), i.Options); err != nil {
+		os.RemoveAll(dir)
+		errMessage := fmt.Sprintf("Failed creating the replica %s:%v", p.VolumeID, err)
+		return failedNBDExport(errMessage, err)
+	}
+	if err := c.exposeReplica(ctx, p.VolumeID, i); err != nil {
+		if err := c.volManager.DeleteVolume(context.Background(), i.QSDID); err != nil {
+			log.Errorf("Failed removing the nodes of %s: %v", i.File, err)
+		}
+		os.RemoveAll(dir)
+		errMessage := fmt.Sprintf("Cannot export the replica %s over NBD: %v", p.VolumeID, err)
+		return failedNBDExport(errMessage, err)
+	}
+	c.images[p.VolumeID] = i
+	c.activeLayers[p.VolumeID] = p.VolumeID
+	c.replications[p.VolumeID] = &replication{Role: replicationReplica}
+	return c.replicaExport(p.VolumeID), nil
+}
+
+func (c *Server) exposeReplica(ctx context.Context, id string, i *QCOWImage) error {
+	if err := c.volManager.StartNBDServer(ctx, c.nbdPort, c.nbdTLSDir); err != nil {
+		return err
+	}
+	return c.volManager.ExposeNBDWritable(ctx, id, i.QSDID)
+}
+
+func (c *Server) replicaExport(id string) *ResponseNBDExport {
+	return &ResponseNBDExport{
+		Success:    true,
+		Port:       c.nbdPort,
+		ExportName: id,
+		TLS:        c.nbdTLSDir != "",
+	}
+}
+
+// StartReplication connects the volume to the export of its replica and starts
+// mirroring the writes
+func (c *Server) StartReplication(ctx context.Context, p *ReplicationParams) (*Response, error) {
+	log.Infof("Replicate volume %s on %s:%s", p.VolumeID, p.Host, p.Port)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i, ok := c.images[p.VolumeID]
+	if !ok {
+		errMessage := fmt.Sprintf("Volume %s not found", p.VolumeID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if r, ok := c.replications[p.VolumeID]; ok {
+		if r.Role != replicationPrimary || r.Host != p.Host || r.ExportName != p.ExportName {
+			errMessage := fmt.Sprintf("Volume %s is already replicated", p.VolumeID)
+			return failed(errMessage, status.Error(codes.AlreadyExists, errMessage))
+		}
+		return &Response{Success: true}, nil
+	}
+	if p.Async && p.RPO <= 0 {
+		errMessage := fmt.Sprintf("Invalid RPO %d for the asynchronous replication of %s", p.RPO, p.VolumeID)
+		return failed(errMessage, status.Error(codes.InvalidArgument, errMessage))
+	}
+	if p.TLS && c.nbdTLSDir == "" {
+		errMessage := "The replica requires TLS but the certificates are not configured"
+		return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+	}
+	r := &replication{
+		Role:       replicationPrimary,
+		Host:       p.Host,
+		Port:       p.Port,
+		ExportName: p.ExportName,
+		TLS:        p.TLS,
+		Async:      p.Async,
+		RPO:        time.Duration(p.RPO) * time.Second,
+	}
+	// The first sync of the asynchronous replications is started by RunReplications
+	if r.Async {
+		c.replications[p.VolumeID] = r
+		return &Response{Success: true}, nil
+	}
+	if err := c.startReplication(ctx, p.VolumeID, i, r); err != nil {
+		errMessage := fmt.Sprintf("Cannot replicate the volume %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	c.replications[p.VolumeID] = r
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+func (c *Server) startReplication(ctx context.Context, id string, i *QCOWImage, r *replication) error {
+	a, ok := c.images[c.activeLayers[id]]
+	if !ok {
+		return fmt.Errorf("Active layer for the volume %s not found", id)
+	}
+	if err := c.volManager.AddReplicaNode(ctx, i.QSDID, r.Host, r.Port, r.ExportName, c.replicaTLSDir(r)); err != nil {
+		return err
+	}
+	if err := c.volManager.StartReplication(ctx, i.QSDID, a.QSDID); err != nil {
+		if err := c.volManager.DeleteReplicaNode(context.Background(), i.QSDID); err != nil {
+			log.Errorf("Failed removing the replica node of %s: %v", id, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Server) replicaTLSDir(r *replication) string {
+	if r.TLS {
+		return c.nbdTLSDir
+	}
+	return ""
+}
+
+// stopReplication stops mirroring the volume and disconnects the replica
+func (c *Server) stopReplication(ctx context.Context, id string) error {
+	i, ok := c.images[id]
+	if !ok {
+		return fmt.Errorf("Volume %s not found", id)
+	}
+	if err := c.volManager.StopReplication(ctx, i.QSDID); err != nil {
+		return err
+	}
+	return c.volManager.DeleteReplicaNode(ctx, i.QSDID)
+}
+
+// pauseReplication stops the mirror of the volume, the block jobs block the
+// snapshots of their source node. It returns false if the volume isn't replicated.
+func (c *Server) pauseReplication(ctx context.Context, id string) (bool, error) {
+	if r, ok := c.replications[id]; !ok || r.Role != replicationPrimary || r.Async {
+		return false, nil
+	}
+	log.Infof("Pause the replication of %s", id)
+	return true, c.stopReplication(ctx, id)
+}
+
+// resumeReplication mirrors again the whole volume from its new active layer, the
+// writes done while the replication was paused aren't tracked
+func (c *Server) resumeReplication(ctx context.Context, id string) {
+	r, ok := c.replications[id]
+	if !ok {
+		return
+	}
+	log.Infof("Resume the replication of %s", id)
+	if err := c.startReplication(ctx, id, c.images[id], r); err != nil {
+		log.Errorf("Failed resuming the replication of %s: %v", id, err)
+	}
+}
+
+// PromoteReplica turns the replica into the volume after the loss of the primary.
+// The volume can then be exported on this node.
+func (c *Server) PromoteReplica(ctx context.Context, image *Image) (*Response, error) {
+	log.Infof("Promote the replica of %s", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.replications[image.ID]
+	if !ok {
+		if _, ok := c.images[image.ID]; ok {
+			// Already promoted
+			return &Response{Success: true}, nil
+		}
+		errMessage := fmt.Sprintf("Replica %s not found", image.ID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if r.Role != replicationReplica {
+		errMessage := fmt.Sprintf("Volume %s is the primary", image.ID)
+		return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+	}
+	// The primary is disconnected from now on
+	if err := c.volManager.ForceDeleteNBDExport(ctx, image.ID); err != nil {
+		errMessage := fmt.Sprintf("Cannot delete the NBD export of the replica %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	delete(c.replications, image.ID)
+	log.Infof("Promoted the replica of %s", image.ID)
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// deleteReplication removes the replication of the deleted volume
+func (c *Server) deleteReplication(ctx context.Context, id string) error {
+	r, ok := c.replications[id]
+	if !ok {
+		return nil
+	}
+	if r.Role == replicationPrimary {
+		if err := c.stopSyncReplication(ctx, id, r); err != nil {
+			return err
+		}
+	} else if err := c.volManager.ForceDeleteNBDExport(ctx, id); err != nil {
+		return err
+	}
+	delete(c.replications, id)
+	return nil
+}
+
+// stopSyncReplication stops the mirror of the synchronous replications, the
+// replica node of the asynchronous ones is only connected during the syncs
+func (c *Server) stopSyncReplication(ctx context.Context, id string, r *replication) error {
+	if r.Async {
+		return nil
+	}
+	return c.stopReplication(ctx, id)
+}
+
+// isReplica returns if the volume is a replica not promoted yet
+func (c *Server) isReplica(id string) bool {
+	r, ok := c.replications[id]
+	return ok && r.Role == replicationReplica
+}
+
+// replicationStatus returns the state, the peer and the lag of the replication of
+// the volume from the block jobs and the dirty bitmaps
+func (c *Server) replicationStatus(id string, jobs []JobInfo, nodes []NameBlockNode) (string, string, int64) {
+	r, ok := c.replications[id]
+	if !ok {
+		return "", "", 0
+	}
+	if r.Role == replicationReplica {
+		return replicationReplica, "", 0
+	}
+	jobID := replicaJob(c.images[id].QSDID)
+	for _, j := range jobs {
+		if j.ID != jobID {
+			continue
+		}
+		lag := j.TotalProgress - j.CurrentProgress
+		switch j.Status {
+		case "ready":
+			return replicationInSync, r.Host, lag
+		case "running", "created", "paused", "waiting", "pending":
+			return replicationSyncing, r.Host, lag
+		}
+		return replicationBroken, r.Host, lag
+	}
+	if !r.Async {
+		return replicationBroken, r.Host, 0
+	}
+	// The lag of the asynchronous replications are the bytes written since the last sync
+	var lag int64
+	if a, ok := c.images[c.activeLayers[id]]; ok && a.QSDID == r.Node {
+		for _, n := range nodes {
+			if n.NodeName != FormatNode(a.QSDID) {
+				continue
+			}
+			for _, b := range n.DirtyBitmaps {
+				if b.Name == ReplicationBitmap {
+					lag = b.Count
+				}
+			}
+		}
+	}
+	switch {
+	case r.LastError != "":
+		return replicationBroken, r.Host, lag
+	case r.LastSync.IsZero():
+		return replicationSyncing, r.Host, lag
+	}
+	return replicationInSync, r.Host, lag
+}
+
+// SyncReplica copies on the replica the writes since the previous sync of an
+// asynchronous replication
+func (c *Server) SyncReplica(ctx context.Context, image *Image) (*Response, error) {
+	log.Infof("Sync the replica of %s", image.ID)
+	if err := c.syncReplica(ctx, image.ID); err != nil {
+		errMessage := fmt.Sprintf("Failed syncing the replica of %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// syncReplica runs a sync without holding the lock. The sync is incremental if the
+// bitmap tracks the active layer since the last sync, and full otherwise, for example
+// after a snapshot or a revert of the volume.
+func (c *Server) syncReplica(ctx context.Context, id string) error {
+	c.mu.Lock()
+	r, ok := c.replications[id]
+	if !ok || r.Role != replicationPrimary || !r.Async {
+		c.mu.Unlock()
+		return status.Errorf(codes.NotFound, "Asynchronous replication of %s not found", id)
+	}
+	i, okI := c.images[id]
+	a, okA := c.images[c.activeLayers[id]]
+	if !okI || !okA {
+		c.mu.Unlock()
+		return status.Errorf(codes.NotFound, "Volume %s not found", id)
+	}
+	if err := c.reserve(id); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	defer c.release(id)
+	prev := *r
+	tlsDir := c.replicaTLSDir(r)
+	volManager := c.volManager
+	c.mu.Unlock()
+
+	n, err := volManager.GetNode(ctx, a.QSDID)
+	if err != nil {
+		return err
+	}
+	bitmap := hasBitmap(n, ReplicationBitmap)
+	incremental := bitmap && !prev.LastSync.IsZero() && prev.Node == a.QSDID
+	if err := volManager.AddReplicaNode(ctx, i.QSDID, prev.Host, prev.Port, prev.ExportName, tlsDir); err != nil {
+		c.syncDone(id, r, a.QSDID, incremental, err)
+		return err
+	}
+	err = volManager.SyncReplica(ctx, i.QSDID, a.QSDID, incremental, !bitmap)
+	if err := volManager.DeleteReplicaNode(context.Background(), i.QSDID); err != nil {
+		log.Errorf("Failed removing the replica node of %s: %v", id, err)
+	}
+	c.syncDone(id, r, a.QSDID, incremental, err)
+	return err
+}
+
+// syncDone records the result of the sync if the replication still exists. A failed
+// full sync has already reset the bitmap, the next sync needs to be full too.
+func (c *Server) syncDone(id string, r *replication, node string, incremental bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.replications[id] != r {
+		return
+	}
+	if err != nil {
+		r.LastError = err.Error()
+		if !incremental {
+			r.Node = ""
+		}
+		return
+	}
+	r.LastError = ""
+	r.LastSync = time.Now()
+	r.Node = node
+	log.Infof("Synced the replica of %s", id)
+}
+
+// RunReplications starts the syncs of the asynchronous replications whose last sync
+// is older than their RPO. The replications are checked every interval until the
+// context is cancelled.
+func (c *Server) RunReplications(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, id := range c.dueReplications(time.Now()) {
+			go func(id string) {
+				if err := c.syncReplica(ctx, id); err != nil && status.Code(err) != codes.Aborted {
+					log.Errorf("Failed syncing the replica of %s: %v", id, err)
+				}
+			}(id)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dueReplications returns the asynchronous replications to sync, the volumes
+// busy with another sync, a backup or a migration are skipped
+func (c *Server) dueReplications(now time.Time) []string {
+	if c.isDraining() {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ids []string
+	for id, r := range c.replications {
+		if r.Role != replicationPrimary || !r.Async || c.busy[id] {
+			continue
+		}
+		if now.Sub(r.LastSync) >= r.RPO {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
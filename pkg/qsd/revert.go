@@ -0,0 +1,181 @@
+package qsd
+
+import (
+	context "context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const revertPrefix = "revert"
+
+// RevertVolume reverts the volume in place to one of its snapshots. The overlay of a
+// snapshot receives the writes done after the snapshot, the content of the snapshot
+// is its backing image. A new active layer is created on top of it and the previous
+// one is discarded. The exports of the volume are stopped during the revert and
+// recreated on the same sockets.
+func (c *Server) RevertVolume(ctx context.Context, p *RevertParams) (*Response, error) {
+	log.Infof("Revert volume %s to snapshot %s", p.VolumeID, p.SnapshotID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.busy[p.VolumeID] {
+		errMessage := fmt.Sprintf("A backup, restore, migration or merge for %s is in progress", p.VolumeID)
+		return failed(errMessage, status.Error(codes.Aborted, errMessage))
+	}
+	if _, ok := c.images[p.VolumeID]; !ok {
+		errMessage := fmt.Sprintf("Volume %s not found", p.VolumeID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	id, ok := c.activeLayers[p.VolumeID]
+	if !ok {
+		errMessage := fmt.Sprintf("Volume %s not found", p.VolumeID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	a, ok := c.images[id]
+	if !ok {
+		errMessage := fmt.Sprintf("Active layer of the volume %s not found", p.VolumeID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	if _, ok := c.internalSnapshots[p.SnapshotID]; ok {
+		errMessage := fmt.Sprintf("Reverting to the internal snapshot %s is not supported", p.SnapshotID)
+		return failed(errMessage, status.Error(codes.Unimplemented, errMessage))
+	}
+	if !c.inChain(id, p.SnapshotID) {
+		errMessage := fmt.Sprintf("Snapshot %s not found for the volume %s", p.SnapshotID, p.VolumeID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	// The mirror blocks the removal of the previous active layer
+	paused, err := c.pauseReplication(ctx, p.VolumeID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Cannot pause the replication of %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	if paused {
+		defer c.resumeReplication(ctx, p.VolumeID)
+	}
+	baseID := c.images[p.SnapshotID].BackingImageID
+	b, ok := c.images[baseID]
+	if !ok {
+		errMessage := fmt.Sprintf("Backing image of the snapshot %s not found", p.SnapshotID)
+		return failed(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	qsdID, err := randomQSDID()
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed generating the id of the new active layer of %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	r := &QCOWImage{
+		QSDID:          qsdID,
+		BackingImageID: baseID,
+		File:           fmt.Sprintf("%s/%s/%s-%s", imagesDir, p.VolumeID, revertPrefix, qsdID),
+		Depth:          b.Depth + 1,
+		Options:        a.Options,
+		Created:        time.Now(),
+	}
+	if err := c.volManager.CreateSnapshotWithBackingNode(ctx, b.QSDID, r.QSDID, b.File, r.File, b.QSDID, r.Options); err != nil {
+		os.Remove(r.File)
+		errMessage := fmt.Sprintf("Cannot create the new active layer of %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	if err := c.stopExports(ctx, p.VolumeID); err != nil {
+		if err := c.volManager.DeleteVolume(context.Background(), r.QSDID); err != nil {
+			log.Errorf("Failed removing the nodes of %s: %v", r.File, err)
+		}
+		os.Remove(r.File)
+		errMessage := fmt.Sprintf("Cannot stop the exports of %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	rID := fmt.Sprintf("%s-%s-%s", p.VolumeID, revertPrefix, qsdID)
+	b.RefCount++
+	c.images[rID] = r
+	c.activeLayers[p.VolumeID] = rID
+	// The writes tracked by the bitmap are lost with the previous active layer
+	delete(c.backups, p.VolumeID)
+	if err := c.startExports(ctx, p.VolumeID); err != nil {
+		errMessage := fmt.Sprintf("Cannot export again the volume %s: %v", p.VolumeID, err)
+		return failed(errMessage, err)
+	}
+	// The previous active layer is kept if it is still a snapshot, a backing image or
+	// stores internal snapshots
+	if a.RefCount < 1 && a.VolumeRef == "" && !c.hasInternalSnapshots(id) {
+		if err := c.deleteImage(ctx, id); err != nil {
+			log.Warningf("Failed deleting the previous active layer of %s: %v", p.VolumeID, err)
+		} else if err := c.deleteNodeWithZeroReference(ctx, a.BackingImageID); err != nil {
+			log.Warningf("Failed cleaning up the zero reference node %s: %v", a.BackingImageID, err)
+		}
+	}
+	log.Infof("Reverted volume %s to snapshot %s", p.VolumeID, p.SnapshotID)
+	return &Response{
+		Success: true,
+	}, nil
+}
+
+// inChain returns if the image is the top image or one of its backing images
+func (c *Server) inChain(top, id string) bool {
+	for top != "" {
+		if top == id {
+			return true
+		}
+		i, ok := c.images[top]
+		if !ok {
+			return false
+		}
+		top = i.BackingImageID
+	}
+	return false
+}
+
+// randomQSDID returns a node id for the images without their own volume or snapshot
+func randomQSDID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// stopExports deletes the vhost-user exports of the volume and keeps their sockets
+// in the state of the server
+func (c *Server) stopExports(ctx context.Context, id string) error {
+	i := c.images[id]
+	if _, ok := c.exports[id]; ok {
+		if err := c.volManager.DeleteExporter(ctx, i.QSDID); err != nil {
+			return err
+		}
+	}
+	for exportID := range c.publishes[id] {
+		if err := c.volManager.DeleteExporter(ctx, publishID(i.QSDID, exportID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startExports creates again the vhost-user exports of the volume on the active layer
+func (c *Server) startExports(ctx context.Context, id string) error {
+	i := c.images[id]
+	a := c.images[c.activeLayers[id]]
+	if socket, ok := c.exports[id]; ok {
+		if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := c.volManager.ExposeVhostUser(ctx, i.QSDID, a.QSDID, socket, true, i.Export); err != nil {
+			return err
+		}
+	}
+	for exportID, p := range c.publishes[id] {
+		if err := os.Remove(p.Socket); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := c.volManager.ExposeVhostUser(ctx, publishID(i.QSDID, exportID), a.QSDID, p.Socket, p.Writable, i.Export); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/alicefr/csi-qsd/pkg/backup"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,14 +32,52 @@ type QCOWImage struct {
 	RefCount       int32
 	VolumeRef      string
 	Depth          uint32
+	PVC            string
+	// Options of the node and, for the volumes, of their exports
+	Options *NodeOptions
+	Export  *ExportOptions
+	Created time.Time
 }
 
 type Server struct {
 	QsdServiceServer
+	// mu protects the images, the active layers and the exports
+	mu           sync.Mutex
 	qsdSock      string
 	images       map[string]*QCOWImage
 	activeLayers map[string]string
-	volManager   *VolumeManager
+	// exports maps the volumes to their vhost-user socket
+	exports map[string]string
+	// publishes contains the additional exports of the volumes, one per publish
+	publishes  map[string]map[string]*publishExport
+	volManager *VolumeManager
+	// healthMu protects the health and the draining flag, the probes don't wait
+	// for the operations holding mu
+	healthMu sync.Mutex
+	health   health
+	// draining is set during the shutdown to refuse the new mutations
+	draining bool
+	uploader backup.Uploader
+	// backups maps the volumes to their last backup
+	backups map[string]*backupState
+	// busy contains the volumes with a backup, a restore or a migration in progress
+	busy map[string]bool
+	// nbdPort and nbdTLSDir configure the NBD server used for the migrations
+	nbdPort   string
+	nbdTLSDir string
+	// nbdExports counts the users of the NBD exports of the volumes and the snapshots
+	nbdExports map[string]int
+	// internalSnapshots contains the snapshots stored in the qcow2 images
+	internalSnapshots map[string]*internalSnapshot
+	// snapshotExports contains the read-only exports of the snapshots, one per publish
+	snapshotExports map[string]map[string]*publishExport
+	// replications contains the role of the server for the replicated volumes
+	replications map[string]*replication
+	// importDir and exportDir contain the local images imported and exported
+	importDir string
+	exportDir string
+	// merges maps the volumes to the unreferenced image being streamed in their chain
+	merges map[string]string
 }
 
 func NewServer(sock string) (*Server, error) {
@@ -45,7 +89,19 @@ func NewServer(sock string) (*Server, error) {
 		qsdSock:      sock,
 		images:       make(map[string]*QCOWImage),
 		activeLayers: make(map[string]string),
+		exports:      make(map[string]string),
+		publishes:    make(map[string]map[string]*publishExport),
+		backups:      make(map[string]*backupState),
+		busy:         make(map[string]bool),
+		nbdExports:   make(map[string]int),
+		nbdPort:      defaultNBDPort,
 		volManager:   volManager,
+		health:       health{status: ResponseHealth_SERVING},
+
+		internalSnapshots: make(map[string]*internalSnapshot),
+		snapshotExports:   make(map[string]map[string]*publishExport),
+		replications:      make(map[string]*replication),
+		merges:            make(map[string]string),
 	}, nil
 }
 
@@ -77,8 +133,24 @@ func generateQSDID(id string) string {
 	return id[:size]
 }
 
+// pvcName returns the namespaced name of the PVC bound to the image if known
+func pvcName(image *Image) string {
+	if image.PVCName == "" {
+		return ""
+	}
+	if image.PVCNamespace == "" {
+		return image.PVCName
+	}
+	return fmt.Sprintf("%s/%s", image.PVCNamespace, image.PVCName)
+}
+
 func (c *Server) CreateVolume(ctx context.Context, image *Image) (*Response, error) {
 	log.Infof("Create Volume %s", image.ID)
+	if err := checkOptions(image.Export, image.IO); err != nil {
+		return failed(err.Error(), err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	dir := fmt.Sprintf("%s/%s", imagesDir, image.ID)
 	// Create directory for the volume if it doesn't exists
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -90,26 +162,46 @@ func (c *Server) CreateVolume(ctx context.Context, image *Image) (*Response, err
 		QSDID:     generateQSDID(image.ID),
 		RefCount:  0,
 		VolumeRef: image.ID,
+		PVC:       pvcName(image),
+		Options:   nodeOptions(image.Export, image.IO),
+		Export:    image.Export,
+		Created:   time.Now(),
 	}
 	if image.FromVolume == "" {
-		if err := c.volManager.CreateVolume(qcowImage.File, qcowImage.QSDID, strconv.FormatInt(image.Size, 10)); err != nil {
+		if err := c.volManager.CreateVolume(ctx, qcowImage.File, qcowImage.QSDID, strconv.FormatInt(image.Size, 10), qcowImage.Options); err != nil {
 			errMessage := fmt.Sprintf("Failed creating the disk image %s:%v", image.ID, err)
 			return failed(errMessage, err)
 		}
 		qcowImage.Depth = 0
+	} else if s, ok := c.internalSnapshots[image.FromVolume]; ok {
+		log.Infof("Create image %s from the internal snapshot %s", image.ID, image.FromVolume)
+		if err := c.cloneInternalSnapshot(ctx, image.FromVolume, s, qcowImage); err != nil {
+			errMessage := fmt.Sprintf("Cannot clone the snapshot %s: %v", image.FromVolume, err)
+			return failed(errMessage, err)
+		}
 	} else {
 		log.Infof("Create image %s from %s", image.ID, image.FromVolume)
-		qcowImage.BackingImageID = image.FromVolume
-		b, ok := c.images[image.FromVolume]
+		backingID := image.FromVolume
+		b, ok := c.images[backingID]
 		if !ok {
 			return &Response{}, fmt.Errorf("Failed to delete the image %s: image not found", image.FromVolume)
 		}
-		if err := c.volManager.CreateSnapshotWithBackingNode(b.QSDID, qcowImage.QSDID, b.File, qcowImage.File, b.QSDID); err != nil {
+		// The overlay of a snapshot is the next layer of the volume, the content
+		// of the snapshot is its backing image as exported by ExportNBD
+		if _, isVolume := c.activeLayers[image.FromVolume]; !isVolume && b.VolumeRef == image.FromVolume {
+			backingID = b.BackingImageID
+			if b, ok = c.images[backingID]; !ok {
+				errMessage := fmt.Sprintf("Backing image %s of the snapshot %s not found", backingID, image.FromVolume)
+				return failed(errMessage, status.Error(codes.NotFound, errMessage))
+			}
+		}
+		qcowImage.BackingImageID = backingID
+		if err := c.volManager.CreateSnapshotWithBackingNode(ctx, b.QSDID, qcowImage.QSDID, b.File, qcowImage.File, b.QSDID, qcowImage.Options); err != nil {
 			errMessage := fmt.Sprintf("Cannot snapshot %s: %v", image.FromVolume, err)
 			return failed(errMessage, err)
 		}
 		b.RefCount++
-		c.images[image.FromVolume] = b
+		c.images[backingID] = b
 		qcowImage.Depth = b.Depth + 1
 
 	}
@@ -122,6 +214,20 @@ func (c *Server) CreateVolume(ctx context.Context, image *Image) (*Response, err
 
 func (c *Server) ExposeVhostUser(ctx context.Context, image *Image) (*Response, error) {
 	log.Infof("Export vhost user for image %s", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isReplica(image.ID) {
+		errMessage := fmt.Sprintf("Volume %s is a replica, it needs to be promoted first", image.ID)
+		return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+	}
+	// The writes wouldn't be in the copy of the volume
+	if c.nbdExports[image.ID] > 0 {
+		errMessage := fmt.Sprintf("Volume %s is being copied to another node", image.ID)
+		return failed(errMessage, status.Error(codes.Aborted, errMessage))
+	}
+	if image.ExportID != "" {
+		return c.exposePublish(ctx, image)
+	}
 	i, ok := c.images[image.ID]
 	if !ok {
 		errMessage := fmt.Sprintf("Image %s not found", image.ID)
@@ -142,10 +248,16 @@ func (c *Server) ExposeVhostUser(ctx context.Context, image *Image) (*Response,
 		}
 	}
 
-	if err := c.volManager.ExposeVhostUser(i.QSDID, socket); err != nil {
+	// Export the active layer of the volume
+	node := i.QSDID
+	if a, ok := c.images[c.activeLayers[image.ID]]; ok {
+		node = a.QSDID
+	}
+	if err := c.volManager.ExposeVhostUser(ctx, i.QSDID, node, socket, true, i.Export); err != nil {
 		errMessage := fmt.Sprintf("Cannot create socket for the volume %s: %v", image.ID, err)
 		return failed(errMessage, err)
 	}
+	c.exports[image.ID] = socket
 	return &Response{
 		Success: true,
 	}, nil
@@ -167,15 +279,26 @@ func deleteIfEmptyDir(path string) error {
 
 func (c *Server) DeleteExporter(ctx context.Context, image *Image) (*Response, error) {
 	log.Infof("Delete exporter %s", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if image.ExportID != "" {
+		return c.deletePublish(ctx, image)
+	}
 	i, ok := c.images[image.ID]
 	if !ok {
 		errMessage := fmt.Sprintf("Image %s not found", image.ID)
 		return failed(errMessage, fmt.Errorf(errMessage))
 	}
-	if err := c.volManager.DeleteExporter(i.QSDID); err != nil {
+	// The driver doesn't create the export of the volume anymore, only the volumes
+	// created before or by the client have it
+	if _, ok := c.exports[image.ID]; !ok {
+		return &Response{}, nil
+	}
+	if err := c.volManager.DeleteExporter(ctx, i.QSDID); err != nil {
 		errMessage := fmt.Sprintf("Cannot delete exporter for volume %s: %v", image.ID, err)
 		return failed(errMessage, err)
 	}
+	delete(c.exports, image.ID)
 	dir := fmt.Sprintf("%s/%s", socketDir, image.ID)
 	if err := os.Remove(dir); err != nil {
 		errMessage := fmt.Sprintf("Cannot delete socket directory for volume %s: %v", image.ID, err)
@@ -184,22 +307,63 @@ func (c *Server) DeleteExporter(ctx context.Context, image *Image) (*Response, e
 	return &Response{}, nil
 }
 
-func (c *Server) CreateSnapshot(ctx context.Context, snapshot *Snapshot) (*Response, error) {
+func failedSnapshot(m string, err error) (*ResponseSnapshot, error) {
+	log.Errorf(m)
+	return &ResponseSnapshot{
+		Success: false,
+		Message: m,
+	}, err
+}
+
+func (c *Server) CreateSnapshot(ctx context.Context, snapshot *Snapshot) (*ResponseSnapshot, error) {
 	log.Infof("Create Snapshot %s of image %s", snapshot.ID, snapshot.SourceVolumeID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkMerge(snapshot.SourceVolumeID); err != nil {
+		return failedSnapshot(err.Error(), err)
+	}
+	dir := fmt.Sprintf("%s/%s", imagesDir, snapshot.SourceVolumeID)
+	if s, ok := c.images[snapshot.ID]; ok {
+		if filepath.Dir(s.File) != dir {
+			errMessage := fmt.Sprintf("Snapshot %s already exists for another volume", snapshot.ID)
+			return failedSnapshot(errMessage, status.Error(codes.AlreadyExists, errMessage))
+		}
+		log.Infof("Snapshot %s already created", snapshot.ID)
+		return c.snapshotResponse(ctx, s)
+	}
+	if s, ok := c.internalSnapshots[snapshot.ID]; ok {
+		if s.Volume != snapshot.SourceVolumeID {
+			errMessage := fmt.Sprintf("Snapshot %s already exists for another volume", snapshot.ID)
+			return failedSnapshot(errMessage, status.Error(codes.AlreadyExists, errMessage))
+		}
+		log.Infof("Snapshot %s already created", snapshot.ID)
+		return c.internalSnapshotResponse(ctx, snapshot.ID, s)
+	}
+	// The mirror blocks the snapshots of the active layer
+	paused, err := c.pauseReplication(ctx, snapshot.SourceVolumeID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Cannot pause the replication of %s: %v", snapshot.SourceVolumeID, err)
+		return failedSnapshot(errMessage, err)
+	}
+	if paused {
+		defer c.resumeReplication(ctx, snapshot.SourceVolumeID)
+	}
+	if snapshot.Internal {
+		return c.createInternalSnapshot(ctx, snapshot)
+	}
 	// Get active layer of the image
 	id, ok := c.activeLayers[snapshot.SourceVolumeID]
 	if !ok {
-		return &Response{}, fmt.Errorf("Failed to delete the image %s: active layer not found", snapshot.SourceVolumeID)
+		return &ResponseSnapshot{}, fmt.Errorf("Failed to delete the image %s: active layer not found", snapshot.SourceVolumeID)
 	}
 	var i *QCOWImage
 	i, ok = c.images[id]
 	if !ok {
-		return &Response{}, fmt.Errorf("Failed to delete the image %s: image not found", snapshot.SourceVolumeID)
+		return &ResponseSnapshot{}, fmt.Errorf("Failed to delete the image %s: image not found", snapshot.SourceVolumeID)
 	}
-	dir := fmt.Sprintf("%s/%s", imagesDir, snapshot.SourceVolumeID)
 	if _, err := os.Stat(dir); err != nil {
 		errMessage := fmt.Sprintf("Failed checking the directory for snapshot %s:%v", snapshot.ID, err)
-		return failed(errMessage, err)
+		return failedSnapshot(errMessage, err)
 	}
 	s := &QCOWImage{
 		QSDID:          generateQSDID(snapshot.ID),
@@ -207,16 +371,24 @@ func (c *Server) CreateSnapshot(ctx context.Context, snapshot *Snapshot) (*Respo
 		File:           fmt.Sprintf("%s/%s-%s", dir, snapshotPrefix, generateQSDID(snapshot.ID)),
 		VolumeRef:      snapshot.ID,
 		Depth:          i.Depth + 1,
+		Options:        i.Options,
+		Created:        time.Now(),
 	}
+	// Keep tracking the writes for the incremental backups on the new active layer
+	b, carryBitmap := c.backups[snapshot.SourceVolumeID]
+	carryBitmap = carryBitmap && b.Node == i.QSDID
 	if i.RefCount < 1 {
-		if err := c.volManager.CreateSnapshot(i.QSDID, s.QSDID, i.File, s.File); err != nil {
+		if err := c.volManager.CreateSnapshot(ctx, i.QSDID, s.QSDID, i.File, s.File, carryBitmap, s.Options); err != nil {
 			errMessage := fmt.Sprintf("Cannot snapshot %s: %v", snapshot.ID, err)
-			return failed(errMessage, err)
+			return failedSnapshot(errMessage, err)
+		}
+		if carryBitmap {
+			b.Node = s.QSDID
 		}
 	} else {
-		if err := c.volManager.CreateSnapshotWithBackingNode(i.QSDID, s.QSDID, i.File, s.File, i.QSDID); err != nil {
+		if err := c.volManager.CreateSnapshotWithBackingNode(ctx, i.QSDID, s.QSDID, i.File, s.File, i.QSDID, s.Options); err != nil {
 			errMessage := fmt.Sprintf("Cannot snapshot %s: %v", snapshot.ID, err)
-			return failed(errMessage, err)
+			return failedSnapshot(errMessage, err)
 		}
 
 	}
@@ -225,12 +397,47 @@ func (c *Server) CreateSnapshot(ctx context.Context, snapshot *Snapshot) (*Respo
 	c.images[snapshot.ID] = s
 	// Update the active layer with the new snapshot
 	c.activeLayers[snapshot.SourceVolumeID] = snapshot.ID
-	return &Response{}, nil
+	return c.snapshotResponse(ctx, s)
+
+}
 
+// snapshotResponse returns the creation time and the sizes of the snapshot. The
+// content of the snapshot is the backing image of its overlay, the image contains
+// the data written since the previous snapshot.
+func (c *Server) snapshotResponse(ctx context.Context, s *QCOWImage) (*ResponseSnapshot, error) {
+	b, ok := c.images[s.BackingImageID]
+	if !ok {
+		errMessage := fmt.Sprintf("Backing image %s of the snapshot not found", s.BackingImageID)
+		return failedSnapshot(errMessage, status.Error(codes.NotFound, errMessage))
+	}
+	n, err := c.volManager.GetNode(ctx, b.QSDID)
+	if err != nil {
+		errMessage := fmt.Sprintf("Failed getting the node of the snapshot %s: %v", s.VolumeRef, err)
+		return failedSnapshot(errMessage, err)
+	}
+	return &ResponseSnapshot{
+		Success:        true,
+		CreationTime:   s.Created.UnixNano(),
+		RestoreSize:    int64(n.Image.VirtualSize),
+		AllocatedBytes: n.Image.ActualSize,
+	}, nil
 }
 
 func (c *Server) DeleteVolume(ctx context.Context, image *Image) (*Response, error) {
 	log.Infof("Delete image %s", image.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkMerge(image.ID); err != nil {
+		return failed(err.Error(), err)
+	}
+	if len(c.publishes[image.ID]) > 0 {
+		errMessage := fmt.Sprintf("Volume %s is still published", image.ID)
+		return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+	}
+	if err := c.deleteReplication(ctx, image.ID); err != nil {
+		errMessage := fmt.Sprintf("Failed removing the replication of %s: %v", image.ID, err)
+		return failed(errMessage, err)
+	}
 	// Get the active layer of the image
 	id, ok := c.activeLayers[image.ID]
 	if !ok {
@@ -241,18 +448,18 @@ func (c *Server) DeleteVolume(ctx context.Context, image *Image) (*Response, err
 	if !ok {
 		return &Response{}, fmt.Errorf("Failed to delete the image %s: image not found", image.ID)
 	}
-	if i.RefCount < 1 && id == image.ID {
-		if err := c.deleteImage(id); err != nil {
+	// The active layer is the image of the volume, a snapshot or, after a revert, a
+	// layer without volume reference. Only the snapshots are kept.
+	if i.RefCount < 1 && (id == image.ID || i.VolumeRef == "") && !c.hasInternalSnapshots(id) {
+		if err := c.deleteImage(ctx, id); err != nil {
 			errMessage := fmt.Sprintf("Failed deleting image %s:%v", image.ID, err)
 			return failed(errMessage, err)
 		}
-	} else {
-		// Remove the volume reference from the image
-		i, ok = c.images[image.ID]
-		if ok {
-			i.VolumeRef = ""
-			c.images[image.ID] = i
-		}
+	}
+	// Remove the volume reference from the image if still used
+	if v, ok := c.images[image.ID]; ok {
+		v.VolumeRef = ""
+		c.images[image.ID] = v
 	}
 	delete(c.activeLayers, image.ID)
 	dir := fmt.Sprintf("%s/%s", imagesDir, image.ID)
@@ -260,41 +467,42 @@ func (c *Server) DeleteVolume(ctx context.Context, image *Image) (*Response, err
 		errMessage := fmt.Sprintf("Cannot delete image directory for the volume %s: %v", image.ID, err)
 		return failed(errMessage, err)
 	}
-	if err := c.deleteNodeWithZeroReference(i.BackingImageID); err != nil {
+	if err := c.deleteNodeWithZeroReference(ctx, i.BackingImageID); err != nil {
 		errMessage := fmt.Sprintf("Failed cleaning up the zero reference node %s: %v", image.ID, err)
 		return failed(errMessage, err)
 	}
 	return &Response{}, nil
 }
 
-func (c *Server) deleteNodeWithZeroReference(id string) error {
+func (c *Server) deleteNodeWithZeroReference(ctx context.Context, id string) error {
 	log.Infof("Cleaning node with zero references %s", id)
 	i, ok := c.images[id]
 	// The image has already been deleted
 	if !ok {
 		return nil
 	}
-	// Don't delete node if there is still a node pointing to the image or a volume reference
-	if i.RefCount > 0 || i.VolumeRef != "" {
+	// Don't delete node if there is still a node pointing to the image, a volume
+	// reference or an internal snapshot
+	if i.RefCount > 0 || i.VolumeRef != "" || c.hasInternalSnapshots(id) {
 		return nil
 	}
 
-	if err := c.deleteImage(id); err != nil {
+	if err := c.deleteImage(ctx, id); err != nil {
 		return err
 	}
 
 	if i.BackingImageID != "" {
-		return c.deleteNodeWithZeroReference(i.BackingImageID)
+		return c.deleteNodeWithZeroReference(ctx, i.BackingImageID)
 	}
 	return nil
 }
 
-func (c *Server) deleteImage(id string) error {
+func (c *Server) deleteImage(ctx context.Context, id string) error {
 	i, ok := c.images[id]
 	if !ok {
 		return fmt.Errorf("Image %s not found", id)
 	}
-	if err := c.volManager.DeleteVolume(i.QSDID); err != nil {
+	if err := c.volManager.DeleteVolume(ctx, i.QSDID); err != nil {
 		return err
 	}
 	if err := os.Remove(i.File); err != nil {
@@ -317,6 +525,18 @@ func (c *Server) deleteImage(id string) error {
 
 func (c *Server) DeleteSnapshot(ctx context.Context, snapshot *Snapshot) (*Response, error) {
 	log.Infof("Delete snapshot %s", snapshot.ID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkMerge(snapshot.SourceVolumeID); err != nil {
+		return failed(err.Error(), err)
+	}
+	if len(c.snapshotExports[snapshot.ID]) > 0 {
+		errMessage := fmt.Sprintf("Snapshot %s is still published", snapshot.ID)
+		return failed(errMessage, status.Error(codes.FailedPrecondition, errMessage))
+	}
+	if s, ok := c.internalSnapshots[snapshot.ID]; ok {
+		return c.deleteInternalSnapshot(ctx, snapshot.ID, s)
+	}
 	s, ok := c.images[snapshot.ID]
 	if !ok {
 		return &Response{}, fmt.Errorf("Failed to get snapshot to delete %s: image not found", snapshot.SourceVolumeID)
@@ -326,7 +546,7 @@ func (c *Server) DeleteSnapshot(ctx context.Context, snapshot *Snapshot) (*Respo
 	isActiveLayer := ok && id == snapshot.ID
 
 	if s.RefCount < 1 && !isActiveLayer {
-		if err := c.deleteImage(snapshot.ID); err != nil {
+		if err := c.deleteImage(ctx, snapshot.ID); err != nil {
 			errMessage := fmt.Sprintf("Failed deleting snapshot %s:%v", snapshot.ID, err)
 			return failed(errMessage, err)
 
@@ -335,17 +555,161 @@ func (c *Server) DeleteSnapshot(ctx context.Context, snapshot *Snapshot) (*Respo
 		s.VolumeRef = ""
 		c.images[snapshot.ID] = s
 	}
-	if err := c.deleteNodeWithZeroReference(s.BackingImageID); err != nil {
+	if err := c.deleteNodeWithZeroReference(ctx, s.BackingImageID); err != nil {
 		errMessage := fmt.Sprintf("Failed cleaning up the zero reference node %s: %v", snapshot.ID, err)
 		return failed(errMessage, err)
 	}
+	// The snapshot can be the unreferenced image or its overlay
+	for _, id := range []string{snapshot.ID, s.BackingImageID} {
+		if err := c.mergeUnreferenced(id); err != nil {
+			log.Warningf("Failed merging the image %s in its overlay: %v", id, err)
+		}
+	}
 	return &Response{}, nil
 }
 
+// mergeUnreferenced streams the image in its only overlay when neither of them is
+// referenced by a volume or a snapshot anymore. Otherwise, the layers of the deleted
+// snapshots stay in the backing chain until the volume is deleted. The stream runs
+// in the background, the two layers and the volumes on top of them are reserved
+// until it completes.
+func (c *Server) mergeUnreferenced(id string) error {
+	i, ok := c.images[id]
+	if !ok || i.VolumeRef != "" || i.RefCount != 1 || i.BackingImageID == "" {
+		return nil
+	}
+	var o *QCOWImage
+	var overlayID string
+	for k, image := range c.images {
+		if image.BackingImageID == id {
+			o, overlayID = image, k
+		}
+	}
+	// The internal snapshots read the unallocated clusters from the backing image
+	if o == nil || o.VolumeRef != "" || c.hasInternalSnapshots(id) || c.hasInternalSnapshots(overlayID) {
+		return nil
+	}
+	b, ok := c.images[i.BackingImageID]
+	if !ok {
+		return fmt.Errorf("Backing image %s not found", i.BackingImageID)
+	}
+	reserved := []string{id, overlayID}
+	for v, active := range c.activeLayers {
+		if c.inChain(active, overlayID) {
+			reserved = append(reserved, v)
+		}
+	}
+	// The layers stay in the chain if another job uses them
+	for _, r := range reserved {
+		if c.busy[r] {
+			return nil
+		}
+	}
+	for _, r := range reserved {
+		c.busy[r] = true
+	}
+	for _, v := range reserved[2:] {
+		c.merges[v] = id
+	}
+	log.Infof("Merge the unreferenced image %s in %s", i.QSDID, o.QSDID)
+	go c.merge(c.volManager, id, overlayID, reserved, b.QSDID, o.QSDID)
+	return nil
+}
+
+// mergeTimeout bounds the background streams, the job is cancelled if the daemon
+// doesn't report its completion
+const mergeTimeout = time.Hour
+
+// merge streams the image in its overlay and removes it once the stream has completed
+func (c *Server) merge(v *VolumeManager, id, overlayID string, reserved []string, base, overlay string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mergeTimeout)
+	defer cancel()
+	err := v.StreamImage(ctx, base, overlay)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.mergeDone(id, overlayID, reserved, err); err != nil {
+		log.Warningf("Failed merging the image %s in its overlay: %v", id, err)
+		return
+	}
+	log.Infof("Merged the image %s in %s", id, overlayID)
+}
+
+// mergeDone releases the layers and the volumes reserved by the merge. After a
+// successful stream, it moves the overlay on the backing image of the merged image,
+// the merged image is then unreferenced and deleted.
+func (c *Server) mergeDone(id, overlayID string, reserved []string, err error) error {
+	for _, r := range reserved {
+		delete(c.busy, r)
+		if c.merges[r] == id {
+			delete(c.merges, r)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	i, ok := c.images[id]
+	if !ok {
+		return fmt.Errorf("Image %s not found", id)
+	}
+	o, ok := c.images[overlayID]
+	if !ok {
+		return fmt.Errorf("Overlay %s not found", overlayID)
+	}
+	b, ok := c.images[i.BackingImageID]
+	if !ok {
+		return fmt.Errorf("Backing image %s not found", i.BackingImageID)
+	}
+	// The overlay and the layers above it are one level closer to the base
+	for k, image := range c.images {
+		if c.inChain(k, overlayID) {
+			image.Depth--
+		}
+	}
+	o.BackingImageID = i.BackingImageID
+	b.RefCount++
+	i.RefCount--
+	return c.deleteImage(context.Background(), id)
+}
+
+// checkMerge returns Aborted while an image in the chain of the volume is merged
+func (c *Server) checkMerge(volumeID string) error {
+	if id, ok := c.merges[volumeID]; ok {
+		return status.Errorf(codes.Aborted, "The image %s of the volume %s is being merged", id, volumeID)
+	}
+	return nil
+}
+
+// countExports returns the number of vhost-user exports of the volume
+func (c *Server) countExports(id string) int {
+	n := len(c.publishes[id])
+	if _, ok := c.exports[id]; ok {
+		n++
+	}
+	return n
+}
+
 func (c *Server) ListVolumes(ctx context.Context, _ *ListVolumesParams) (*ResponseListVolumes, error) {
 	log.Infof("List the images")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var jobs []JobInfo
+	var nodes []NameBlockNode
+	if len(c.replications) > 0 {
+		var err error
+		if jobs, err = c.volManager.GetJobs(ctx); err != nil {
+			log.Warningf("Failed getting the state of the replications: %v", err)
+		}
+		if nodes, err = c.volManager.GetNameBlockNodes(ctx); err != nil {
+			log.Warningf("Failed getting the bitmaps of the replications: %v", err)
+		}
+	}
 	var volumes []*Volume
 	for k, v := range c.images {
+		state, peer, lag := c.replicationStatus(k, jobs, nodes)
+		var lastSync int64
+		if r, ok := c.replications[k]; ok && !r.LastSync.IsZero() {
+			lastSync = r.LastSync.UnixNano()
+		}
 		volumes = append(volumes, &Volume{
 			QSDID:          v.QSDID,
 			BackingImageID: v.BackingImageID,
@@ -353,6 +717,14 @@ func (c *Server) ListVolumes(ctx context.Context, _ *ListVolumesParams) (*Respon
 			RefCount:       v.RefCount,
 			Depth:          v.Depth,
 			VolumeRef:      k,
+			Export:         v.Export,
+			IO:             v.Options.ioOptions(),
+
+			ReplicationState: state,
+			ReplicaPeer:      peer,
+			ReplicationLag:   lag,
+			LastSync:         lastSync,
+			Exports:          int32(c.countExports(k)),
 		})
 	}
 	return &ResponseListVolumes{
@@ -0,0 +1,337 @@
+package qsd
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readOnlyMethods can still be served while the server is draining
+var readOnlyMethods = map[string]bool{
+	"ListVolumes":      true,
+	"Health":           true,
+	"SnapshotMetadata": true,
+}
+
+// state is the part of the server persisted across restarts of the pod
+type state struct {
+	Images       map[string]*QCOWImage                `json:"images"`
+	ActiveLayers map[string]string                    `json:"activeLayers"`
+	Exports      map[string]string                    `json:"exports"`
+	Publishes    map[string]map[string]*publishExport `json:"publishes"`
+	Backups      map[string]*backupState              `json:"backups"`
+	// InternalSnapshots maps the internal snapshots to the images storing them
+	InternalSnapshots map[string]*internalSnapshot `json:"internalSnapshots"`
+	// SnapshotExports are the read-only exports of the snapshots
+	SnapshotExports map[string]map[string]*publishExport `json:"snapshotExports"`
+	// Replications are restarted with a full copy of the volumes, the asynchronous ones
+	// continue from the bitmap
+	Replications map[string]*replication `json:"replications"`
+}
+
+// UnaryInterceptor refuses the requests modifying the volumes once the server
+// started draining
+func (c *Server) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	prefix := fmt.Sprintf("/%s/", QsdService_ServiceDesc.ServiceName)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, prefix) && !readOnlyMethods[strings.TrimPrefix(info.FullMethod, prefix)] && c.isDraining() {
+			return nil, status.Error(codes.Unavailable, "the server is shutting down")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor refuses the streaming requests once the server started draining
+func (c *Server) StreamInterceptor() grpc.StreamServerInterceptor {
+	prefix := fmt.Sprintf("/%s/", QsdService_ServiceDesc.ServiceName)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, prefix) && !readOnlyMethods[strings.TrimPrefix(info.FullMethod, prefix)] && c.isDraining() {
+			return status.Error(codes.Unavailable, "the server is shutting down")
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (c *Server) isDraining() bool {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	return c.draining
+}
+
+// Drain refuses the new mutations, the requests in progress are completed
+func (c *Server) Drain() {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	log.Infof("Draining the server")
+	c.draining = true
+}
+
+// teardown lists the nodes and the exports to remove from the daemon during the
+// shutdown
+type teardown struct {
+	replicas []string
+	// mirrors maps the volumes replicated synchronously to their nodes
+	mirrors map[string]string
+	// exporters are the ids of the vhost-user exports
+	exporters map[string]string
+	nbd       []string
+	// images are sorted with the overlays before their backing nodes
+	images []*QCOWImage
+}
+
+// shutdownTeardown collects the nodes and the exports of the server, the NBD
+// exports of the migrations are dropped since they aren't restored. It must be
+// called with mu held.
+func (c *Server) shutdownTeardown() *teardown {
+	t := &teardown{mirrors: make(map[string]string), exporters: make(map[string]string)}
+	for id, r := range c.replications {
+		if r.Role == replicationReplica {
+			t.replicas = append(t.replicas, id)
+		} else if !r.Async {
+			if i, ok := c.images[id]; ok {
+				t.mirrors[id] = i.QSDID
+			}
+		}
+	}
+	for id := range c.exports {
+		if i, ok := c.images[id]; ok {
+			t.exporters[i.QSDID] = fmt.Sprintf("the export of the volume %s", id)
+		}
+	}
+	for id, publishes := range c.publishes {
+		i, ok := c.images[id]
+		if !ok {
+			continue
+		}
+		for exportID := range publishes {
+			t.exporters[publishID(i.QSDID, exportID)] = fmt.Sprintf("the export %s of the volume %s", exportID, id)
+		}
+	}
+	for id, exports := range c.snapshotExports {
+		s, ok := c.images[id]
+		if !ok {
+			continue
+		}
+		for exportID := range exports {
+			t.exporters[publishID(s.QSDID, exportID)] = fmt.Sprintf("the export %s of the snapshot %s", exportID, id)
+		}
+	}
+	for id := range c.nbdExports {
+		t.nbd = append(t.nbd, id)
+		delete(c.nbdExports, id)
+	}
+	for _, i := range c.images {
+		t.images = append(t.images, i)
+	}
+	sort.Slice(t.images, func(i, j int) bool { return t.images[i].Depth > t.images[j].Depth })
+	return t
+}
+
+// Shutdown waits for or cancels the running block jobs, removes the exports and
+// the nodes and terminates the qemu-storage-daemon. The state of the server is
+// saved in stateFile to be restored at the next start. The lock of the server is
+// only held to collect and save the state, the health checks are answered during
+// the shutdown.
+func (c *Server) Shutdown(ctx context.Context, stateFile string) error {
+	c.Drain()
+	log.Infof("Shutdown the qemu-storage-daemon")
+	c.mu.Lock()
+	t := c.shutdownTeardown()
+	c.mu.Unlock()
+	// The mirrors of the replications never complete
+	for _, id := range t.replicas {
+		if err := c.volManager.ForceDeleteNBDExport(ctx, id); err != nil {
+			log.Errorf("Failed stopping the replication of %s: %v", id, err)
+		}
+	}
+	for id, qsdID := range t.mirrors {
+		err := c.volManager.StopReplication(ctx, qsdID)
+		if err == nil {
+			err = c.volManager.DeleteReplicaNode(ctx, qsdID)
+		}
+		if err != nil {
+			log.Errorf("Failed stopping the replication of %s: %v", id, err)
+		}
+	}
+	if err := c.stopJobs(ctx); err != nil {
+		log.Errorf("Failed stopping the block jobs: %v", err)
+	}
+	for id, desc := range t.exporters {
+		if err := c.volManager.ForceDeleteExporter(ctx, id); err != nil {
+			log.Errorf("Failed removing %s: %v", desc, err)
+		}
+	}
+	// The NBD exports are only used during the migrations and aren't restored
+	for _, id := range t.nbd {
+		if err := c.volManager.ForceDeleteNBDExport(ctx, id); err != nil {
+			log.Errorf("Failed removing the NBD export of %s: %v", id, err)
+		}
+	}
+	// Delete the overlays before their backing nodes, blockdev-del flushes the images
+	for _, i := range t.images {
+		if err := c.volManager.DeleteVolume(ctx, i.QSDID); err != nil {
+			log.Errorf("Failed removing the node for the image %s: %v", i.File, err)
+		}
+	}
+	if stateFile != "" {
+		c.mu.Lock()
+		err := c.saveState(stateFile)
+		c.mu.Unlock()
+		if err != nil {
+			log.Errorf("Failed saving the state: %v", err)
+		}
+	}
+	// The daemon closes the monitor while quitting and the response can get lost
+	if err := c.volManager.Quit(ctx); err != nil {
+		log.Warningf("Quit command: %v", err)
+	}
+	c.volManager.Disconnect()
+	return ctx.Err()
+}
+
+// stopJobs waits for the block jobs to finish and cancels them when half of the
+// shutdown deadline is elapsed
+func (c *Server) stopJobs(ctx context.Context) error {
+	waitCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+		defer cancel()
+	}
+	if c.waitJobs(waitCtx) == nil {
+		return nil
+	}
+	jobs, err := c.volManager.GetJobs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		if isJobFinished(j) {
+			continue
+		}
+		log.Infof("Cancel the job %s", j.ID)
+		if err := c.volManager.CancelJob(ctx, j.ID); err != nil {
+			log.Errorf("Failed cancelling the job %s: %v", j.ID, err)
+		}
+	}
+	return c.waitJobs(ctx)
+}
+
+func (c *Server) waitJobs(ctx context.Context) error {
+	for {
+		jobs, err := c.volManager.GetJobs(ctx)
+		if err != nil {
+			return err
+		}
+		running := 0
+		for _, j := range jobs {
+			if !isJobFinished(j) {
+				running++
+			}
+		}
+		if running == 0 {
+			return nil
+		}
+		log.Infof("Waiting for %d block jobs", running)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func isJobFinished(j JobInfo) bool {
+	return j.Status == "concluded" || j.Status == "null"
+}
+
+func (c *Server) saveState(stateFile string) error {
+	data, err := json.Marshal(&state{
+		Images:       c.images,
+		ActiveLayers: c.activeLayers,
+		Exports:      c.exports,
+		Publishes:    c.publishes,
+		Backups:      c.backups,
+
+		InternalSnapshots: c.internalSnapshots,
+		SnapshotExports:   c.snapshotExports,
+		Replications:      c.replications,
+	})
+	if err != nil {
+		return err
+	}
+	// Write the state atomically to not leave a partial file
+	tmp, err := ioutil.TempFile(filepath.Dir(stateFile), "state")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	log.Infof("Saved the state in %s", stateFile)
+	return os.Rename(tmp.Name(), stateFile)
+}
+
+// LoadState restores the state saved by a previous shutdown and recreates the
+// nodes and the exports on the qemu-storage-daemon
+func (c *Server) LoadState(ctx context.Context, stateFile string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Failed parsing the state %s: %v", stateFile, err)
+	}
+	log.Infof("Restore the state from %s", stateFile)
+	if s.Images != nil {
+		c.images = s.Images
+	}
+	if s.ActiveLayers != nil {
+		c.activeLayers = s.ActiveLayers
+	}
+	if s.Exports != nil {
+		c.exports = s.Exports
+	}
+	if s.Publishes != nil {
+		c.publishes = s.Publishes
+	}
+	if s.Backups != nil {
+		c.backups = s.Backups
+	}
+	if s.InternalSnapshots != nil {
+		c.internalSnapshots = s.InternalSnapshots
+	}
+	if s.SnapshotExports != nil {
+		c.snapshotExports = s.SnapshotExports
+	}
+	if s.Replications != nil {
+		c.replications = s.Replications
+	}
+	if err := c.replay(ctx); err != nil {
+		return err
+	}
+	// The state is only valid until the next modification
+	return os.Remove(stateFile)
+}
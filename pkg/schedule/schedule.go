@@ -0,0 +1,320 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	Group = "qsd.csi.com"
+	Kind  = "QsdSnapshotSchedule"
+
+	// labelSchedule is set on the VolumeSnapshots created by a schedule
+	labelSchedule = "qsd.csi.com/snapshot-schedule"
+)
+
+// ScheduleResource is the custom resource describing when to snapshot the PVCs
+var ScheduleResource = schema.GroupVersionResource{
+	Group:    Group,
+	Version:  "v1alpha1",
+	Resource: "qsdsnapshotschedules",
+}
+
+// SnapshotResource are the VolumeSnapshots of the external snapshotter
+var SnapshotResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1",
+	Resource: "volumesnapshots",
+}
+
+// Spec is the spec of a QsdSnapshotSchedule
+type Spec struct {
+	// Schedule is a standard cron expression
+	Schedule                string                `json:"schedule"`
+	Selector                *metav1.LabelSelector `json:"selector,omitempty"`
+	VolumeSnapshotClassName string                `json:"volumeSnapshotClassName,omitempty"`
+	Retention               Retention             `json:"retention,omitempty"`
+}
+
+// Retention limits the snapshots kept for each PVC, a zero value is unlimited
+type Retention struct {
+	Count  int    `json:"count,omitempty"`
+	MaxAge string `json:"maxAge,omitempty"`
+}
+
+// Status records the runs of a QsdSnapshotSchedule
+type Status struct {
+	LastRunTime     *metav1.Time `json:"lastRunTime,omitempty"`
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	NextRunTime     *metav1.Time `json:"nextRunTime,omitempty"`
+	// Failures counts the consecutive failed runs
+	Failures  int    `json:"failures,omitempty"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Scheduler creates the VolumeSnapshots of the PVCs selected by the
+// QsdSnapshotSchedules and prunes them following their retention
+type Scheduler struct {
+	client     kubernetes.Interface
+	dynClient  dynamic.Interface
+	interval   time.Duration
+	driverName string
+}
+
+func NewScheduler(client kubernetes.Interface, dynClient dynamic.Interface, interval time.Duration, driverName string) *Scheduler {
+	return &Scheduler{
+		client:     client,
+		dynClient:  dynClient,
+		interval:   interval,
+		driverName: driverName,
+	}
+}
+
+// Run checks the schedules every interval and blocks until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) error {
+	log.Infof("Scheduler started")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.syncAll(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) syncAll(ctx context.Context, now time.Time) {
+	list, err := s.dynClient.Resource(ScheduleResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed listing the snapshot schedules: %v", err)
+		return
+	}
+	for i := range list.Items {
+		u := &list.Items[i]
+		if err := s.sync(ctx, u, now); err != nil {
+			log.Errorf("Failed syncing the snapshot schedule %s/%s: %v", u.GetNamespace(), u.GetName(), err)
+		}
+	}
+}
+
+func (s *Scheduler) sync(ctx context.Context, u *unstructured.Unstructured, now time.Time) error {
+	var spec Spec
+	var st Status
+	if err := fromUnstructured(u, "spec", &spec); err != nil {
+		return err
+	}
+	if err := fromUnstructured(u, "status", &st); err != nil {
+		return err
+	}
+	prev := st
+	sched, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		st.LastError = fmt.Sprintf("invalid schedule %q: %v", spec.Schedule, err)
+		return s.updateStatus(ctx, u, &st)
+	}
+	last := u.GetCreationTimestamp().Time
+	if st.LastRunTime != nil {
+		last = st.LastRunTime.Time
+	}
+	if next := sched.Next(last); !now.Before(next) {
+		log.Infof("Run the snapshot schedule %s/%s", u.GetNamespace(), u.GetName())
+		st.LastRunTime = &metav1.Time{Time: now}
+		if err := s.snapshot(ctx, u, &spec, next); err != nil {
+			st.Failures++
+			st.LastError = err.Error()
+		} else {
+			st.Failures = 0
+			st.LastError = ""
+			st.LastSuccessTime = &metav1.Time{Time: now}
+		}
+	}
+	if err := s.prune(ctx, u, &spec, now); err != nil {
+		st.LastError = err.Error()
+	}
+	st.NextRunTime = &metav1.Time{Time: sched.Next(now)}
+	if reflect.DeepEqual(prev, st) {
+		return nil
+	}
+	return s.updateStatus(ctx, u, &st)
+}
+
+// snapshot creates a VolumeSnapshot for each bound PVC of the driver selected by the
+// schedule
+func (s *Scheduler) snapshot(ctx context.Context, u *unstructured.Unstructured, spec *Spec, slot time.Time) error {
+	// All the PVCs of the namespace are selected without selector
+	var selector string
+	if spec.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return fmt.Errorf("invalid selector: %v", err)
+		}
+		selector = sel.String()
+	}
+	pvcs, err := s.client.CoreV1().PersistentVolumeClaims(u.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	var failed []string
+	provisioners := make(map[string]string)
+	for _, pvc := range pvcs.Items {
+		if pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+		ok, err := s.isDriverPVC(ctx, &pvc, provisioners)
+		if err != nil {
+			log.Errorf("Failed getting the provisioner of the PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+			failed = append(failed, pvc.Name)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := s.createSnapshot(ctx, u, spec, pvc.Name, slot); err != nil {
+			log.Errorf("Failed snapshotting the PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+			failed = append(failed, pvc.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed snapshotting the PVCs: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// isDriverPVC returns if the PVC is provisioned by the driver, the provisioners of the
+// StorageClasses are cached in provisioners
+func (s *Scheduler) isDriverPVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim, provisioners map[string]string) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	name := *pvc.Spec.StorageClassName
+	provisioner, ok := provisioners[name]
+	if !ok {
+		sc, err := s.client.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err == nil {
+			provisioner = sc.Provisioner
+		}
+		provisioners[name] = provisioner
+	}
+	return provisioner == s.driverName, nil
+}
+
+func (s *Scheduler) createSnapshot(ctx context.Context, u *unstructured.Unstructured, spec *Spec, pvc string, slot time.Time) error {
+	snapshotSpec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvc,
+		},
+	}
+	if spec.VolumeSnapshotClassName != "" {
+		snapshotSpec["volumeSnapshotClassName"] = spec.VolumeSnapshotClassName
+	}
+	vs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": SnapshotResource.GroupVersion().String(),
+		"kind":       "VolumeSnapshot",
+		"spec":       snapshotSpec,
+	}}
+	// The name depends on the scheduled time, a run repeated because the status
+	// couldn't be updated doesn't create a second snapshot
+	vs.SetName(fmt.Sprintf("%s-%s-%s", u.GetName(), pvc, slot.UTC().Format("20060102-1504")))
+	vs.SetNamespace(u.GetNamespace())
+	// The snapshots are not owned by the schedule to survive its deletion
+	vs.SetLabels(map[string]string{labelSchedule: u.GetName()})
+	log.Infof("Create the VolumeSnapshot %s/%s", vs.GetNamespace(), vs.GetName())
+	_, err := s.dynClient.Resource(SnapshotResource).Namespace(u.GetNamespace()).Create(ctx, vs, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// prune deletes the snapshots of each PVC exceeding the retention. The snapshots are
+// deleted from the oldest, the driver merges the layers of the deleted snapshots in
+// the backing chain only once the following snapshot is deleted too.
+func (s *Scheduler) prune(ctx context.Context, u *unstructured.Unstructured, spec *Spec, now time.Time) error {
+	var maxAge time.Duration
+	if spec.Retention.MaxAge != "" {
+		var err error
+		if maxAge, err = time.ParseDuration(spec.Retention.MaxAge); err != nil {
+			return fmt.Errorf("invalid retention max age %q: %v", spec.Retention.MaxAge, err)
+		}
+	}
+	if spec.Retention.Count == 0 && maxAge == 0 {
+		return nil
+	}
+	list, err := s.dynClient.Resource(SnapshotResource).Namespace(u.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", labelSchedule, u.GetName()),
+	})
+	if err != nil {
+		return err
+	}
+	byPVC := make(map[string][]unstructured.Unstructured)
+	for _, vs := range list.Items {
+		pvc, _, _ := unstructured.NestedString(vs.Object, "spec", "source", "persistentVolumeClaimName")
+		byPVC[pvc] = append(byPVC[pvc], vs)
+	}
+	for pvc, snapshots := range byPVC {
+		// Newest first
+		sort.Slice(snapshots, func(i, j int) bool {
+			return snapshots[j].GetCreationTimestamp().Time.Before(snapshots[i].GetCreationTimestamp().Time)
+		})
+		var expired []unstructured.Unstructured
+		for i, vs := range snapshots {
+			ready, _, _ := unstructured.NestedBool(vs.Object, "status", "readyToUse")
+			if !ready || vs.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if (spec.Retention.Count > 0 && i >= spec.Retention.Count) ||
+				(maxAge > 0 && now.Sub(vs.GetCreationTimestamp().Time) > maxAge) {
+				expired = append(expired, vs)
+			}
+		}
+		for i := len(expired) - 1; i >= 0; i-- {
+			log.Infof("Prune the VolumeSnapshot %s/%s of %s", expired[i].GetNamespace(), expired[i].GetName(), pvc)
+			err := s.dynClient.Resource(SnapshotResource).Namespace(u.GetNamespace()).Delete(ctx, expired[i].GetName(), metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) updateStatus(ctx context.Context, u *unstructured.Unstructured, st *Status) error {
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(st)
+	if err != nil {
+		return err
+	}
+	u = u.DeepCopy()
+	if err := unstructured.SetNestedMap(u.Object, status, "status"); err != nil {
+		return err
+	}
+	_, err = s.dynClient.Resource(ScheduleResource).Namespace(u.GetNamespace()).UpdateStatus(ctx, u, metav1.UpdateOptions{})
+	return err
+}
+
+func fromUnstructured(u *unstructured.Unstructured, field string, obj interface{}) error {
+	m, ok, err := unstructured.NestedMap(u.Object, field)
+	if err != nil || !ok {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(m, obj)
+}
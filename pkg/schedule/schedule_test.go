@@ -0,0 +1,193 @@
+package schedule
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testDriver = "qsd.csi.com"
+
+var now = time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+
+func newSchedule(schedule string, created time.Time, retention map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": Group + "/v1alpha1",
+		"kind":       Kind,
+		"spec": map[string]interface{}{
+			"schedule":  schedule,
+			"retention": retention,
+		},
+	}}
+	u.SetName("daily")
+	u.SetNamespace("default")
+	u.SetCreationTimestamp(metav1.Time{Time: created})
+	return u
+}
+
+func newSnapshot(name, pvc string, created time.Time, ready bool) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": SnapshotResource.GroupVersion().String(),
+		"kind":       "VolumeSnapshot",
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"persistentVolumeClaimName": pvc},
+		},
+		"status": map[string]interface{}{"readyToUse": ready},
+	}}
+	u.SetName(name)
+	u.SetNamespace("default")
+	u.SetLabels(map[string]string{labelSchedule: "daily"})
+	u.SetCreationTimestamp(metav1.Time{Time: created})
+	return u
+}
+
+func newPVC(name, storageClass string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func newTestScheduler(objects []runtime.Object, dynObjects ...runtime.Object) *Scheduler {
+	objects = append(objects,
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "qsd"}, Provisioner: testDriver},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Provisioner: "other.csi.com"},
+	)
+	dynClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			ScheduleResource: "QsdSnapshotScheduleList",
+			SnapshotResource: "VolumeSnapshotList",
+		}, dynObjects...)
+	return NewScheduler(fake.NewSimpleClientset(objects...), dynClient, time.Minute, testDriver)
+}
+
+func snapshotNames(t *testing.T, s *Scheduler) []string {
+	list, err := s.dynClient.Resource(SnapshotResource).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, vs := range list.Items {
+		names = append(names, vs.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSync(t *testing.T) {
+	pvcs := []runtime.Object{
+		newPVC("disk", "qsd", corev1.ClaimBound),
+		newPVC("pending", "qsd", corev1.ClaimPending),
+		newPVC("other", "other", corev1.ClaimBound),
+		newPVC("missing-class", "missing", corev1.ClaimBound),
+	}
+	tests := []struct {
+		name      string
+		schedule  string
+		created   time.Time
+		snapshots []string
+		lastRun   bool
+		lastError bool
+	}{
+		{name: "due", schedule: "0 * * * *", created: now.Add(-2 * time.Hour), snapshots: []string{"daily-disk-20210601-1100"}, lastRun: true},
+		{name: "not due", schedule: "0 * * * *", created: now.Add(-10 * time.Minute)},
+		{name: "invalid schedule", schedule: "every hour", created: now.Add(-2 * time.Hour), lastError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newSchedule(tt.schedule, tt.created, nil)
+			s := newTestScheduler(pvcs, u)
+			if err := s.sync(context.Background(), u, now); err != nil {
+				t.Fatal(err)
+			}
+			if got := snapshotNames(t, s); !equal(got, tt.snapshots) {
+				t.Errorf("snapshots %v, want %v", got, tt.snapshots)
+			}
+			got, err := s.dynClient.Resource(ScheduleResource).Namespace("default").Get(context.Background(), "daily", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var st Status
+			if err := fromUnstructured(got, "status", &st); err != nil {
+				t.Fatal(err)
+			}
+			if (st.LastRunTime != nil) != tt.lastRun {
+				t.Errorf("last run %v, want run %v", st.LastRunTime, tt.lastRun)
+			}
+			if tt.lastRun && (st.LastSuccessTime == nil || !st.LastSuccessTime.Time.Equal(now)) {
+				t.Errorf("last success %v, want %v", st.LastSuccessTime, now)
+			}
+			if (st.LastError != "") != tt.lastError {
+				t.Errorf("last error %q, want error %v", st.LastError, tt.lastError)
+			}
+			if !tt.lastError && (st.NextRunTime == nil || !st.NextRunTime.Time.Equal(time.Date(2021, 6, 1, 13, 0, 0, 0, time.UTC))) {
+				t.Errorf("next run %v, want 13:00", st.NextRunTime)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	snapshots := func() []runtime.Object {
+		return []runtime.Object{
+			newSnapshot("disk-1", "disk", now.Add(-4*time.Hour), true),
+			newSnapshot("disk-2", "disk", now.Add(-3*time.Hour), true),
+			newSnapshot("disk-3", "disk", now.Add(-2*time.Hour), true),
+			newSnapshot("disk-4", "disk", now.Add(-1*time.Hour), true),
+			newSnapshot("disk-5", "disk", now.Add(-10*time.Minute), false),
+			newSnapshot("data-1", "data", now.Add(-4*time.Hour), true),
+		}
+	}
+	tests := []struct {
+		name      string
+		retention map[string]interface{}
+		want      []string
+		fail      bool
+	}{
+		{name: "unlimited", want: []string{"data-1", "disk-1", "disk-2", "disk-3", "disk-4", "disk-5"}},
+		{name: "count", retention: map[string]interface{}{"count": int64(3)}, want: []string{"data-1", "disk-3", "disk-4", "disk-5"}},
+		{name: "max age", retention: map[string]interface{}{"maxAge": "150m"}, want: []string{"disk-3", "disk-4", "disk-5"}},
+		{name: "count and max age", retention: map[string]interface{}{"count": int64(2), "maxAge": "150m"}, want: []string{"disk-4", "disk-5"}},
+		{name: "invalid max age", retention: map[string]interface{}{"maxAge": "a week"}, want: []string{"data-1", "disk-1", "disk-2", "disk-3", "disk-4", "disk-5"}, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newSchedule("0 0 * * *", now, tt.retention)
+			s := newTestScheduler(nil, append(snapshots(), u)...)
+			var spec Spec
+			if err := fromUnstructured(u, "spec", &spec); err != nil {
+				t.Fatal(err)
+			}
+			err := s.prune(context.Background(), u, &spec, now)
+			if (err != nil) != tt.fail {
+				t.Fatalf("prune error %v, want failure %v", err, tt.fail)
+			}
+			if got := snapshotNames(t, s); !equal(got, tt.want) {
+				t.Errorf("snapshots %v, want %v", got, tt.want)
+			}
+		})
+	}
+}